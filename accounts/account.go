@@ -0,0 +1,121 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// Client is the subset of the access API used to create and manage accounts.
+// access.Client satisfies it.
+type Client interface {
+	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
+	GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error)
+	SendTransaction(ctx context.Context, tx flow.Transaction) error
+	GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error)
+}
+
+// Key is a proposal key of an account together with its locally tracked sequence number.
+type Key struct {
+	Index          uint32
+	SequenceNumber uint64
+}
+
+// Account is an account the load generator holds the private key of.
+// All keys of the account share the same private key.
+type Account struct {
+	Address    flow.Address
+	PrivateKey crypto.PrivateKey
+	HashAlgo   crypto.HashAlgorithm
+	Keys       []*Key
+
+	signer crypto.Signer
+}
+
+func NewAccount(
+	address flow.Address,
+	privateKey crypto.PrivateKey,
+	hashAlgo crypto.HashAlgorithm,
+	keys []*Key,
+) (*Account, error) {
+	signer, err := crypto.NewInMemorySigner(privateKey, hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer for account %s: %w", address, err)
+	}
+
+	return &Account{
+		Address:    address,
+		PrivateKey: privateKey,
+		HashAlgo:   hashAlgo,
+		Keys:       keys,
+		signer:     signer,
+	}, nil
+}
+
+// FetchAccount returns the account at address with all non-revoked keys matching privateKey,
+// using the sequence numbers currently stored on chain.
+func FetchAccount(
+	ctx context.Context,
+	client Client,
+	address flow.Address,
+	privateKey crypto.PrivateKey,
+	hashAlgo crypto.HashAlgorithm,
+) (*Account, error) {
+	account, err := NewAccount(address, privateKey, hashAlgo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = account.Sync(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if len(account.Keys) == 0 {
+		return nil, fmt.Errorf("account %s has no keys matching the private key", address)
+	}
+	return account, nil
+}
+
+// Sync replaces the keys of the account with the non-revoked on-chain keys matching its private key.
+func (a *Account) Sync(ctx context.Context, client Client) error {
+	onChain, err := client.GetAccount(ctx, a.Address)
+	if err != nil {
+		return fmt.Errorf("failed to get account %s: %w", a.Address, err)
+	}
+
+	publicKey := a.PrivateKey.PublicKey()
+	keys := make([]*Key, 0, len(onChain.Keys))
+	for _, key := range onChain.Keys {
+		if key.Revoked || !key.PublicKey.Equals(publicKey) {
+			continue
+		}
+		keys = append(keys, &Key{
+			Index:          key.Index,
+			SequenceNumber: key.SequenceNumber,
+		})
+	}
+	a.Keys = keys
+	return nil
+}
+
+// SignAsSoleSigner sets the account as proposer (using key), payer and authorizer of tx
+// and signs the envelope.
+// The key's sequence number is not advanced; call IncrementSequenceNumber once tx was sent.
+func (a *Account) SignAsSoleSigner(tx *flow.Transaction, key *Key) error {
+	tx.SetProposalKey(a.Address, key.Index, key.SequenceNumber).
+		SetPayer(a.Address).
+		AddAuthorizer(a.Address)
+
+	err := tx.SignEnvelope(a.Address, key.Index, a.signer)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction with key %d of account %s: %w", key.Index, a.Address, err)
+	}
+	return nil
+}
+
+// IncrementSequenceNumber advances the locally tracked sequence number of the key.
+func (k *Key) IncrementSequenceNumber() {
+	k.SequenceNumber++
+}
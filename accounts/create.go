@@ -0,0 +1,182 @@
+package accounts
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-standard-transactions/transactions"
+)
+
+const resultPollInterval = 250 * time.Millisecond
+
+type CreateConfig struct {
+	// NumAccounts is the total number of accounts to create.
+	NumAccounts uint64
+	// BatchSize is the number of accounts created per transaction.
+	BatchSize uint64
+	// KeysPerAccount is the number of proposal keys added to each account.
+	KeysPerAccount uint64
+	// FundingAmount is the FLOW balance, in UFix64 units, transferred to each account.
+	FundingAmount uint64
+
+	SignatureAlgorithm crypto.SignatureAlgorithm
+	HashAlgorithm      crypto.HashAlgorithm
+
+	ComputeLimit uint64
+	Imports      transactions.Imports
+}
+
+// CreateAccounts creates and funds accounts in batches, paid and signed by the service account.
+// The first key of the service account is used as the proposal key.
+// If a batch fails, the accounts created by the earlier batches are returned along with the error.
+func CreateAccounts(
+	ctx context.Context,
+	client Client,
+	service *Account,
+	config CreateConfig,
+) ([]*Account, error) {
+	if config.BatchSize == 0 {
+		return nil, fmt.Errorf("batch size must be greater than zero")
+	}
+	if len(service.Keys) == 0 {
+		return nil, fmt.Errorf("service account %s has no keys", service.Address)
+	}
+
+	accounts := make([]*Account, 0, config.NumAccounts)
+	for uint64(len(accounts)) < config.NumAccounts {
+		batchSize := min(config.BatchSize, config.NumAccounts-uint64(len(accounts)))
+
+		batch, err := createAccountBatch(ctx, client, service, batchSize, config)
+		if err != nil {
+			return accounts, err
+		}
+		accounts = append(accounts, batch...)
+	}
+	return accounts, nil
+}
+
+func createAccountBatch(
+	ctx context.Context,
+	client Client,
+	service *Account,
+	batchSize uint64,
+	config CreateConfig,
+) ([]*Account, error) {
+	privateKeys := make([]crypto.PrivateKey, batchSize)
+	publicKeys := make([]crypto.PublicKey, batchSize)
+	for i := range privateKeys {
+		seed := make([]byte, crypto.MinSeedLength)
+		_, err := rand.Read(seed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate seed: %w", err)
+		}
+
+		privateKey, err := crypto.GeneratePrivateKey(config.SignatureAlgorithm, seed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate private key: %w", err)
+		}
+		privateKeys[i] = privateKey
+		publicKeys[i] = privateKey.PublicKey()
+	}
+
+	template, err := transactions.NewCreateNewAccountsWithKeysTransaction(
+		publicKeys,
+		config.HashAlgorithm,
+		config.KeysPerAccount,
+		config.FundingAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	script := transactions.Render(template, config.Imports)
+
+	referenceBlock, err := client.GetLatestBlockHeader(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference block: %w", err)
+	}
+
+	key := service.Keys[0]
+	tx := flow.NewTransaction().
+		SetScript([]byte(script)).
+		SetReferenceBlockID(referenceBlock.ID).
+		SetComputeLimit(config.ComputeLimit)
+
+	err = service.SignAsSoleSigner(tx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.SendTransaction(ctx, *tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send account creation transaction: %w", err)
+	}
+	key.IncrementSequenceNumber()
+
+	result, err := WaitForSeal(ctx, client, tx.ID())
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("account creation transaction %s failed: %w", tx.ID(), result.Error)
+	}
+
+	addresses := make([]flow.Address, 0, batchSize)
+	for _, event := range result.Events {
+		if event.Type == flow.EventAccountCreated {
+			addresses = append(addresses, flow.AccountCreatedEvent(event).Address())
+		}
+	}
+	if uint64(len(addresses)) != batchSize {
+		return nil, fmt.Errorf(
+			"account creation transaction %s created %d accounts, expected %d",
+			tx.ID(),
+			len(addresses),
+			batchSize,
+		)
+	}
+
+	accounts := make([]*Account, 0, batchSize)
+	for i, address := range addresses {
+		keys := make([]*Key, config.KeysPerAccount)
+		for k := range keys {
+			keys[k] = &Key{Index: uint32(k)}
+		}
+
+		account, err := NewAccount(address, privateKeys[i], config.HashAlgorithm, keys)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// WaitForSeal polls the result of the transaction until it is sealed or ctx is done.
+func WaitForSeal(ctx context.Context, client Client, txID flow.Identifier) (*flow.TransactionResult, error) {
+	ticker := time.NewTicker(resultPollInterval)
+	defer ticker.Stop()
+
+	for {
+		result, err := client.GetTransactionResult(ctx, txID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get result of transaction %s: %w", txID, err)
+		}
+		switch result.Status {
+		case flow.TransactionStatusSealed:
+			return result, nil
+		case flow.TransactionStatusExpired:
+			return nil, fmt.Errorf("transaction %s expired", txID)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-standard-transactions/transactions"
+)
+
+// ProposalKey is a single key of an account handed out by a KeyPool.
+type ProposalKey struct {
+	Account *Account
+	Key     *Key
+
+	mu sync.Mutex
+	// stale is set when a result reported an invalid sequence number
+	stale bool
+	// outstanding is the number of sent transactions whose result was not reported yet
+	outstanding int
+	// parked is set while a stale key is withheld from borrowers until outstanding drops to zero
+	parked bool
+	// parkings counts how often the key was parked, so a park timeout only unparks the parking it was set for
+	parkings int
+	// parkTimer unparks the key after the park timeout
+	parkTimer *time.Timer
+}
+
+// Sign sets the key as proposal key, its account as payer and authorizer of tx,
+// and signs the envelope.
+func (k *ProposalKey) Sign(tx *flow.Transaction) error {
+	return k.Account.SignAsSoleSigner(tx, k.Key)
+}
+
+// Sent advances the sequence number of the key after a transaction proposed with it was sent.
+// The result of the transaction must be reported with KeyPool.ReportResult.
+func (k *ProposalKey) Sent() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.Key.IncrementSequenceNumber()
+	k.outstanding++
+}
+
+// KeyPool hands out individual proposal keys to concurrent workers,
+// so that many transactions can be in flight for the same account.
+// A borrowed key is used exclusively by one worker until it is returned.
+//
+// Sequence numbers are tracked locally: a worker calls ProposalKey.Sent after sending a transaction
+// and returns the key right away, without waiting for the result.
+// If a result later reports an invalid sequence number, the key is withheld until the results
+// of all transactions in flight with it were reported, and then resynchronized from chain,
+// so the resynchronized sequence number does not collide with transactions still in flight.
+// As the results of some transactions are never reported, e.g. when the tracker gives up on them,
+// a withheld key is resynchronized after the park timeout even if results are outstanding.
+type KeyPool struct {
+	client    Client
+	keys      []*ProposalKey
+	available chan *ProposalKey
+
+	mu          sync.Mutex
+	parkTimeout time.Duration
+}
+
+// DefaultParkTimeout is longer than the expiry of transactions, about 600 blocks,
+// so transactions whose results were not reported are no longer in flight when a withheld key is resynchronized.
+const DefaultParkTimeout = 15 * time.Minute
+
+func NewKeyPool(client Client, accounts []*Account) *KeyPool {
+	// interleave the keys of all accounts, so consecutive borrows use different accounts
+	keys := make([]*ProposalKey, 0)
+	for i := 0; ; i++ {
+		added := false
+		for _, account := range accounts {
+			if i < len(account.Keys) {
+				keys = append(keys, &ProposalKey{
+					Account: account,
+					Key:     account.Keys[i],
+				})
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	available := make(chan *ProposalKey, len(keys))
+	for _, key := range keys {
+		available <- key
+	}
+
+	return &KeyPool{
+		client:      client,
+		keys:        keys,
+		available:   available,
+		parkTimeout: DefaultParkTimeout,
+	}
+}
+
+// SetParkTimeout sets the time after which a stale key with outstanding results is resynchronized
+// and handed out again. Zero disables the timeout. Defaults to DefaultParkTimeout.
+func (p *KeyPool) SetParkTimeout(timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.parkTimeout = timeout
+}
+
+// Borrow blocks until a key is available or ctx is done.
+// Keys marked as stale are resynchronized before they are handed out.
+func (p *KeyPool) Borrow(ctx context.Context) (*ProposalKey, error) {
+	for {
+		var key *ProposalKey
+		select {
+		case key = <-p.available:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		key.mu.Lock()
+		stale := key.stale
+		if stale && key.outstanding > 0 {
+			// ReportResult makes the key available again once the last outstanding result is reported,
+			// or the park timeout does
+			p.park(key)
+			key.mu.Unlock()
+			continue
+		}
+		key.mu.Unlock()
+
+		if stale {
+			err := p.resync(ctx, key)
+			if err != nil {
+				p.available <- key
+				return nil, err
+			}
+		}
+		return key, nil
+	}
+}
+
+// Return makes a borrowed key available again.
+func (p *KeyPool) Return(key *ProposalKey) {
+	p.available <- key
+}
+
+// Size returns the number of keys in the pool.
+func (p *KeyPool) Size() int {
+	return len(p.keys)
+}
+
+// ReportResult inspects the result error of a transaction proposed with key
+// and marks the key as stale if the sequence number was invalid.
+// It reports whether the key was marked as stale.
+// It must be called once for each transaction the key was marked as Sent for.
+// The key does not need to be borrowed.
+func (p *KeyPool) ReportResult(key *ProposalKey, err error) bool {
+	invalid := IsInvalidSequenceNumberError(err)
+
+	key.mu.Lock()
+	if key.outstanding > 0 {
+		key.outstanding--
+	}
+	if invalid {
+		key.stale = true
+	}
+	unpark := key.parked && key.outstanding == 0
+	if unpark {
+		key.parked = false
+		if key.parkTimer != nil {
+			key.parkTimer.Stop()
+			key.parkTimer = nil
+		}
+	}
+	key.mu.Unlock()
+
+	if unpark {
+		p.available <- key
+	}
+	return invalid
+}
+
+// park withholds the key until its outstanding results are reported or the park timeout expires.
+// The key must be locked.
+func (p *KeyPool) park(key *ProposalKey) {
+	key.parked = true
+	key.parkings++
+
+	p.mu.Lock()
+	timeout := p.parkTimeout
+	p.mu.Unlock()
+	if timeout <= 0 {
+		return
+	}
+
+	parking := key.parkings
+	key.parkTimer = time.AfterFunc(timeout, func() {
+		p.parkTimedOut(key, parking)
+	})
+}
+
+// parkTimedOut makes the key available again, giving up on its outstanding results.
+// Results of the given up transactions that are still reported are counted
+// against the transactions sent after the key is resynchronized.
+func (p *KeyPool) parkTimedOut(key *ProposalKey, parking int) {
+	key.mu.Lock()
+	if !key.parked || key.parkings != parking {
+		key.mu.Unlock()
+		return
+	}
+	key.parked = false
+	key.parkTimer = nil
+	key.outstanding = 0
+	key.mu.Unlock()
+
+	p.available <- key
+}
+
+func (p *KeyPool) resync(ctx context.Context, key *ProposalKey) error {
+	account, err := p.client.GetAccount(ctx, key.Account.Address)
+	if err != nil {
+		return fmt.Errorf("failed to get account %s: %w", key.Account.Address, err)
+	}
+
+	for _, onChain := range account.Keys {
+		if onChain.Index == key.Key.Index {
+			key.mu.Lock()
+			key.Key.SequenceNumber = onChain.SequenceNumber
+			key.stale = false
+			key.mu.Unlock()
+			return nil
+		}
+	}
+	return fmt.Errorf("key %d not found on account %s", key.Key.Index, key.Account.Address)
+}
+
+// IsInvalidSequenceNumberError reports whether err is a transaction result error
+// caused by an invalid proposal key sequence number.
+func IsInvalidSequenceNumberError(err error) bool {
+	code, ok := transactions.ErrorCode(err)
+	return ok && code == transactions.ErrCodeInvalidProposalSeqNumber
+}
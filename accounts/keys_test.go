@@ -0,0 +1,118 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-standard-transactions/transactions"
+)
+
+// keysClient returns the account with the sequence number of its only key.
+type keysClient struct {
+	Client
+	sequenceNumber uint64
+}
+
+func (c *keysClient) GetAccount(_ context.Context, address flow.Address) (*flow.Account, error) {
+	return &flow.Account{
+		Address: address,
+		Keys:    []*flow.AccountKey{{Index: 0, SequenceNumber: c.sequenceNumber}},
+	}, nil
+}
+
+var errInvalidSequenceNumber = fmt.Errorf(
+	"[Error Code: %d] invalid proposal key",
+	transactions.ErrCodeInvalidProposalSeqNumber,
+)
+
+// newStaleKeyPool returns a pool of one key, which is stale and has one outstanding result.
+func newStaleKeyPool(t *testing.T) (*KeyPool, *ProposalKey) {
+	account := &Account{
+		Address: flow.HexToAddress("01"),
+		Keys:    []*Key{{Index: 0}},
+	}
+	pool := NewKeyPool(&keysClient{sequenceNumber: 7}, []*Account{account})
+
+	key, err := pool.Borrow(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.Sent()
+	key.Sent()
+	pool.Return(key)
+
+	if !pool.ReportResult(key, errInvalidSequenceNumber) {
+		t.Fatal("key was not marked as stale")
+	}
+	return pool, key
+}
+
+func requireResynchronized(t *testing.T, pool *KeyPool, key *ProposalKey) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	borrowed, err := pool.Borrow(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if borrowed != key {
+		t.Fatal("borrowed another key")
+	}
+	if key.Key.SequenceNumber != 7 {
+		t.Errorf("sequence number %d was not resynchronized", key.Key.SequenceNumber)
+	}
+}
+
+func TestKeyPoolUnparksWhenResultsAreReported(t *testing.T) {
+	pool, key := newStaleKeyPool(t)
+
+	go func() {
+		// wait until the key is parked by Borrow
+		for {
+			key.mu.Lock()
+			parked := key.parked
+			key.mu.Unlock()
+			if parked {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		pool.ReportResult(key, nil)
+	}()
+
+	requireResynchronized(t, pool, key)
+}
+
+func TestKeyPoolUnparksAfterTimeout(t *testing.T) {
+	pool, key := newStaleKeyPool(t)
+	pool.SetParkTimeout(10 * time.Millisecond)
+
+	requireResynchronized(t, pool, key)
+
+	key.mu.Lock()
+	outstanding := key.outstanding
+	key.mu.Unlock()
+	if outstanding != 0 {
+		t.Errorf("%d results are still outstanding", outstanding)
+	}
+}
+
+func TestKeyPoolParkTimeoutDisabled(t *testing.T) {
+	pool, _ := newStaleKeyPool(t)
+	pool.SetParkTimeout(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := pool.Borrow(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the key to stay parked, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// Pool hands out accounts to concurrent workers.
+// A borrowed account is used exclusively by one worker until it is returned,
+// so the sequence numbers of its keys never collide.
+type Pool struct {
+	accounts  []*Account
+	available chan *Account
+
+	mu sync.Mutex
+	// borrowed records, for each account of the pool, whether it is currently borrowed.
+	borrowed map[*Account]bool
+}
+
+func NewPool(accounts []*Account) *Pool {
+	available := make(chan *Account, len(accounts))
+	borrowed := make(map[*Account]bool, len(accounts))
+	for _, account := range accounts {
+		available <- account
+		borrowed[account] = false
+	}
+
+	return &Pool{
+		accounts:  accounts,
+		available: available,
+		borrowed:  borrowed,
+	}
+}
+
+// Borrow blocks until an account is available or ctx is done.
+func (p *Pool) Borrow(ctx context.Context) (*Account, error) {
+	select {
+	case account := <-p.available:
+		p.mu.Lock()
+		p.borrowed[account] = true
+		p.mu.Unlock()
+		return account, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Return makes a borrowed account available again.
+// It panics if the account is not part of the pool or is not currently borrowed,
+// as returning it would otherwise block forever on the full pool.
+func (p *Pool) Return(account *Account) {
+	p.mu.Lock()
+	borrowed, ok := p.borrowed[account]
+	if !ok {
+		p.mu.Unlock()
+		panic(fmt.Sprintf("account %s is not part of the pool", account.Address))
+	}
+	if !borrowed {
+		p.mu.Unlock()
+		panic(fmt.Sprintf("account %s is returned but not borrowed", account.Address))
+	}
+	p.borrowed[account] = false
+	p.mu.Unlock()
+
+	p.available <- account
+}
+
+// Accounts returns all accounts of the pool, borrowed or not.
+func (p *Pool) Accounts() []*Account {
+	return p.accounts
+}
+
+// Sync refreshes the keys and sequence numbers of all accounts from chain.
+// It must not be called while accounts are borrowed.
+func (p *Pool) Sync(ctx context.Context, client Client) error {
+	for _, account := range p.accounts {
+		err := account.Sync(ctx, client)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type keyJSON struct {
+	Index          uint32 `json:"index"`
+	SequenceNumber uint64 `json:"sequenceNumber"`
+}
+
+type accountJSON struct {
+	Address    flow.Address `json:"address"`
+	PrivateKey string       `json:"privateKey"`
+	SigAlgo    string       `json:"sigAlgo"`
+	HashAlgo   string       `json:"hashAlgo"`
+	Keys       []keyJSON    `json:"keys"`
+}
+
+// Save writes all accounts of the pool to a JSON file at path.
+// It must not be called while accounts are borrowed.
+func (p *Pool) Save(path string) error {
+	entries := make([]accountJSON, 0, len(p.accounts))
+	for _, account := range p.accounts {
+		keys := make([]keyJSON, 0, len(account.Keys))
+		for _, key := range account.Keys {
+			keys = append(keys, keyJSON{
+				Index:          key.Index,
+				SequenceNumber: key.SequenceNumber,
+			})
+		}
+
+		entries = append(entries, accountJSON{
+			Address:    account.Address,
+			PrivateKey: hex.EncodeToString(account.PrivateKey.Encode()),
+			SigAlgo:    account.PrivateKey.Algorithm().String(),
+			HashAlgo:   account.HashAlgo.String(),
+			Keys:       keys,
+		})
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode accounts: %w", err)
+	}
+
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write accounts to %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadPool reads a pool from a JSON file written by Save.
+// The stored sequence numbers may be stale; call Sync before using the accounts.
+func LoadPool(path string) (*Pool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accounts from %s: %w", path, err)
+	}
+
+	var entries []accountJSON
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode accounts from %s: %w", path, err)
+	}
+
+	accounts := make([]*Account, 0, len(entries))
+	for _, entry := range entries {
+		sigAlgo := crypto.StringToSignatureAlgorithm(entry.SigAlgo)
+		privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, entry.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode private key of account %s: %w", entry.Address, err)
+		}
+
+		keys := make([]*Key, 0, len(entry.Keys))
+		for _, key := range entry.Keys {
+			keys = append(keys, &Key{
+				Index:          key.Index,
+				SequenceNumber: key.SequenceNumber,
+			})
+		}
+
+		account, err := NewAccount(
+			entry.Address,
+			privateKey,
+			crypto.StringToHashAlgorithm(entry.HashAlgo),
+			keys,
+		)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return NewPool(accounts), nil
+}
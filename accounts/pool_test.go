@@ -0,0 +1,41 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func requirePanics(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	f()
+}
+
+func TestPoolReturn(t *testing.T) {
+	account := &Account{Address: flow.HexToAddress("01")}
+	pool := NewPool([]*Account{account})
+
+	requirePanics(t, func() { pool.Return(account) })
+	requirePanics(t, func() { pool.Return(&Account{Address: flow.HexToAddress("02")}) })
+
+	borrowed, err := pool.Borrow(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.Return(borrowed)
+	requirePanics(t, func() { pool.Return(borrowed) })
+
+	borrowed, err = pool.Borrow(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if borrowed != account {
+		t.Errorf("borrowed %s, expected %s", borrowed.Address, account.Address)
+	}
+}
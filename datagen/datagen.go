@@ -0,0 +1,286 @@
+package datagen
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// Shape is the kind of container at a level of the generated tree.
+type Shape string
+
+const (
+	ShapeArray      Shape = "array"
+	ShapeDictionary Shape = "dictionary"
+	// ShapeStruct nodes are TestContract.Node structs, with the children in an [AnyStruct] array.
+	ShapeStruct Shape = "struct"
+)
+
+// KeyDistribution is how the keys of generated dictionaries are chosen.
+type KeyDistribution string
+
+const (
+	// KeysSequential are zero-padded indices, e.g. k0000001, which are inserted in sorted order.
+	KeysSequential KeyDistribution = "sequential"
+	// KeysRandom are random alphanumeric strings.
+	KeysRandom KeyDistribution = "random"
+	// KeysCommonPrefix share a prefix of all but the last few bytes,
+	// which makes key comparisons expensive.
+	KeysCommonPrefix KeyDistribution = "common-prefix"
+)
+
+// Config controls the shape and size of generated data.
+type Config struct {
+	// Depth is the number of container levels above the leaves. Zero generates a single leaf.
+	Depth int
+	// FanOut is the number of children of each container.
+	FanOut int
+	// Shapes are the container kinds of the levels, from the root down, repeated if shorter than Depth.
+	// Defaults to dictionaries.
+	Shapes []Shape
+	// Keys is the distribution of dictionary keys. Defaults to KeysSequential.
+	Keys KeyDistribution
+	// KeyLength is the length of dictionary keys in bytes. Defaults to 8.
+	KeyLength int
+	// LeafSize is the length of the leaf strings in bytes. Ignored if TotalSize is set.
+	LeafSize int
+	// TotalSize, if set, determines LeafSize so the leaves and keys add up to about TotalSize bytes.
+	TotalSize int
+	// NodeType is the type of struct nodes. Defaults to NodeType(nil).
+	NodeType *cadence.StructType
+	// Seed makes the generated data reproducible.
+	Seed uint64
+}
+
+// NodeType returns the type of TestContract.Node, the struct nodes of generated trees.
+// The location is only needed for JSON-CDC: it is the address of the account TestContract is deployed to.
+func NodeType(location common.Location) *cadence.StructType {
+	childrenType := cadence.NewVariableSizedArrayType(cadence.AnyStructType)
+	return cadence.NewStructType(
+		location,
+		"TestContract.Node",
+		[]cadence.Field{
+			cadence.NewField("label", cadence.StringType),
+			cadence.NewField("children", childrenType),
+		},
+		[][]cadence.Parameter{
+			{
+				{Label: "label", Identifier: "label", Type: cadence.StringType},
+				{Label: "children", Identifier: "children", Type: childrenType},
+			},
+		},
+	)
+}
+
+const (
+	defaultKeyLength = 8
+	alphanumeric     = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+type generator struct {
+	config   Config
+	random   *rand.Rand
+	leafSize int
+	keyIndex int
+}
+
+// Generate builds a tree of data according to config.
+func Generate(config Config) (cadence.Value, error) {
+	err := config.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		config: config,
+		random: rand.New(rand.NewPCG(config.Seed, config.Seed)),
+	}
+	g.leafSize = config.leafSize()
+
+	value, _ := g.generate(0)
+	return value, nil
+}
+
+func (c *Config) normalize() error {
+	if c.Depth < 0 {
+		return fmt.Errorf("depth must not be negative, got %d", c.Depth)
+	}
+	if c.Depth > 0 && c.FanOut <= 0 {
+		return fmt.Errorf("fan-out must be positive, got %d", c.FanOut)
+	}
+	if len(c.Shapes) == 0 {
+		c.Shapes = []Shape{ShapeDictionary}
+	}
+	for _, shape := range c.Shapes {
+		switch shape {
+		case ShapeArray, ShapeDictionary, ShapeStruct:
+		default:
+			return fmt.Errorf("unknown shape %q", shape)
+		}
+	}
+	if c.Keys == "" {
+		c.Keys = KeysSequential
+	}
+	switch c.Keys {
+	case KeysSequential, KeysRandom, KeysCommonPrefix:
+	default:
+		return fmt.Errorf("unknown key distribution %q", c.Keys)
+	}
+	if c.KeyLength == 0 {
+		c.KeyLength = defaultKeyLength
+	}
+	if c.KeyLength < 0 || c.LeafSize < 0 || c.TotalSize < 0 {
+		return fmt.Errorf("sizes must not be negative")
+	}
+	if c.NodeType == nil {
+		c.NodeType = NodeType(nil)
+	}
+	return nil
+}
+
+// shape returns the container kind at the level.
+func (c Config) shape(level int) Shape {
+	return c.Shapes[level%len(c.Shapes)]
+}
+
+// Leaves returns the number of leaves of the generated tree, or math.MaxInt if it overflows.
+func (c Config) Leaves() int {
+	leaves := 1
+	for range c.Depth {
+		if c.FanOut > 0 && leaves > math.MaxInt/c.FanOut {
+			return math.MaxInt
+		}
+		leaves *= c.FanOut
+	}
+	return leaves
+}
+
+// keyCount returns the number of dictionary keys of the generated tree, or math.MaxInt if it overflows.
+func (c Config) keyCount() int {
+	count := 0
+	containers := 1
+	for level := range c.Depth {
+		if c.shape(level) == ShapeDictionary {
+			count = saturatingAdd(count, saturatingMul(containers, c.FanOut))
+		}
+		containers = saturatingMul(containers, c.FanOut)
+	}
+	return count
+}
+
+// keyLength returns the length of dictionary keys, applying the default.
+func (c Config) keyLength() int {
+	if c.KeyLength == 0 {
+		return defaultKeyLength
+	}
+	return c.KeyLength
+}
+
+// leafSize returns the length of the leaf strings, derived from TotalSize if it is set.
+func (c Config) leafSize() int {
+	if c.TotalSize == 0 {
+		return c.LeafSize
+	}
+	keyBytes := saturatingMul(c.keyCount(), c.keyLength())
+	return max((c.TotalSize-keyBytes)/c.Leaves(), 0)
+}
+
+// Size returns the number of bytes of the leaf strings and dictionary keys of the generated tree,
+// or math.MaxInt if it overflows. It is a lower bound of the size of the encoded tree,
+// which can be checked before the tree is generated.
+func (c Config) Size() int {
+	leafBytes := saturatingMul(c.Leaves(), c.leafSize())
+	keyBytes := saturatingMul(c.keyCount(), c.keyLength())
+	return saturatingAdd(leafBytes, keyBytes)
+}
+
+func saturatingMul(a int, b int) int {
+	if a > 0 && b > 0 && a > math.MaxInt/b {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+func saturatingAdd(a int, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// generate returns the subtree at the level and its static type.
+func (g *generator) generate(level int) (cadence.Value, cadence.Type) {
+	if level == g.config.Depth {
+		return cadence.String(g.randomString(g.leafSize)), cadence.StringType
+	}
+
+	children := make([]cadence.Value, g.config.FanOut)
+	var childType cadence.Type
+	for i := range children {
+		children[i], childType = g.generate(level + 1)
+	}
+
+	switch g.config.shape(level) {
+	case ShapeArray:
+		arrayType := cadence.NewVariableSizedArrayType(childType)
+		return cadence.NewArray(children).WithType(arrayType), arrayType
+
+	case ShapeDictionary:
+		pairs := make([]cadence.KeyValuePair, len(children))
+		for i, child := range children {
+			pairs[i] = cadence.KeyValuePair{
+				Key:   cadence.String(g.key()),
+				Value: child,
+			}
+		}
+		dictionaryType := cadence.NewDictionaryType(cadence.StringType, childType)
+		return cadence.NewDictionary(pairs).WithType(dictionaryType), dictionaryType
+
+	default:
+		node := cadence.NewStruct([]cadence.Value{
+			cadence.String(fmt.Sprintf("n%d", level)),
+			cadence.NewArray(children).
+				WithType(cadence.NewVariableSizedArrayType(cadence.AnyStructType)),
+		}).WithType(g.config.NodeType)
+		return node, g.config.NodeType
+	}
+}
+
+// key returns the next dictionary key. Keys are unique within the generated tree.
+func (g *generator) key() string {
+	index := g.keyIndex
+	g.keyIndex++
+
+	length := g.config.KeyLength
+	switch g.config.Keys {
+	case KeysRandom:
+		// the index suffix keeps the keys unique
+		suffix := fmt.Sprintf("%x", index)
+		return g.randomString(max(length-len(suffix), 0)) + suffix
+	case KeysCommonPrefix:
+		suffix := fmt.Sprintf("%x", index)
+		return strings.Repeat("p", max(length-len(suffix), 0)) + suffix
+	default:
+		return fmt.Sprintf("k%0*d", max(length-1, 0), index)
+	}
+}
+
+func (g *generator) randomString(length int) string {
+	builder := strings.Builder{}
+	builder.Grow(length)
+	for range length {
+		builder.WriteByte(alphanumeric[g.random.IntN(len(alphanumeric))])
+	}
+	return builder.String()
+}
+
+// JSONCDC encodes the value as a JSON-CDC transaction argument.
+// Struct nodes must have the location of the deployed TestContract, see NodeType.
+func JSONCDC(value cadence.Value) ([]byte, error) {
+	return jsoncdc.Encode(value)
+}
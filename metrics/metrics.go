@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-standard-transactions/transactions"
+)
+
+const (
+	labelLabel     = "label"
+	errorCodeLabel = "error_code"
+	grpcCodeLabel  = "grpc_code"
+
+	// errorCodeExpired is reported as the error code of expired transactions
+	errorCodeExpired = "expired"
+	// errorCodeNoResult is reported as the error code of transactions the tracker gave up on
+	errorCodeNoResult = "no_result"
+	// errorCodeUnknown is reported for failed transactions without an FVM error code
+	errorCodeUnknown = "unknown"
+)
+
+// Collector collects per-label metrics of a load generation run.
+// It implements prometheus.Collector.
+type Collector struct {
+	submitted        *prometheus.CounterVec
+	succeeded        *prometheus.CounterVec
+	failed           *prometheus.CounterVec
+	submissionFailed *prometheus.CounterVec
+	computationUsed  *prometheus.CounterVec
+	events           *prometheus.CounterVec
+
+	latency     *prometheus.HistogramVec
+	computation *prometheus.HistogramVec
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+func NewCollector() *Collector {
+	return &Collector{
+		submitted: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "transactions_submitted_total",
+				Help: "Number of submitted transactions.",
+			},
+			[]string{labelLabel},
+		),
+		succeeded: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "transactions_succeeded_total",
+				Help: "Number of sealed transactions without error.",
+			},
+			[]string{labelLabel},
+		),
+		failed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "transactions_failed_total",
+				Help: "Number of failed or expired transactions, by FVM error code.",
+			},
+			[]string{labelLabel, errorCodeLabel},
+		),
+		submissionFailed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "transactions_submission_failed_total",
+				Help: "Number of transactions rejected by the access API, by gRPC status code.",
+			},
+			[]string{labelLabel, grpcCodeLabel},
+		),
+		computationUsed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "transactions_computation_used_total",
+				Help: "Computation used by sealed transactions.",
+			},
+			[]string{labelLabel},
+		),
+		events: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "transactions_events_total",
+				Help: "Number of events emitted by sealed transactions.",
+			},
+			[]string{labelLabel},
+		),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "transactions_latency_seconds",
+				Help:    "Latency from submission to sealing of a transaction.",
+				Buckets: prometheus.ExponentialBuckets(0.25, 2, 10),
+			},
+			[]string{labelLabel},
+		),
+		computation: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "transactions_computation",
+				Help:    "Computation used by a sealed transaction.",
+				Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+			},
+			[]string{labelLabel},
+		),
+	}
+}
+
+// Submitted records the submission of a transaction.
+func (c *Collector) Submitted(label transactions.Label) {
+	c.submitted.WithLabelValues(label).Inc()
+}
+
+// SubmissionFailed records a transaction that was rejected when it was submitted.
+// Submission errors are gRPC errors, which carry no FVM error code, so they are counted by gRPC status code.
+// Errors that are not gRPC errors are counted as Unknown.
+func (c *Collector) SubmissionFailed(label transactions.Label, err error) {
+	c.submissionFailed.WithLabelValues(label, status.Code(err).String()).Inc()
+}
+
+// Result records the final result of a sealed or expired transaction,
+// or of a transaction the tracker gave up on.
+// It can be registered with tracker.Tracker.OnResult.
+func (c *Collector) Result(label transactions.Label, result *flow.TransactionResult, latency time.Duration) {
+	switch result.Status {
+	case flow.TransactionStatusExpired:
+		c.failed.WithLabelValues(label, errorCodeExpired).Inc()
+		return
+	case flow.TransactionStatusUnknown:
+		c.failed.WithLabelValues(label, errorCodeNoResult).Inc()
+		return
+	}
+
+	if result.Error != nil {
+		c.failed.WithLabelValues(label, errorCode(result.Error)).Inc()
+	} else {
+		c.succeeded.WithLabelValues(label).Inc()
+	}
+
+	c.computationUsed.WithLabelValues(label).Add(float64(result.ComputationUsage))
+	c.events.WithLabelValues(label).Add(float64(len(result.Events)))
+	c.latency.WithLabelValues(label).Observe(latency.Seconds())
+	c.computation.WithLabelValues(label).Observe(float64(result.ComputationUsage))
+}
+
+func errorCode(err error) string {
+	code, ok := transactions.ErrorCode(err)
+	if !ok {
+		return errorCodeUnknown
+	}
+	return strconv.Itoa(code)
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.submitted,
+		c.succeeded,
+		c.failed,
+		c.submissionFailed,
+		c.computationUsed,
+		c.events,
+		c.latency,
+		c.computation,
+	}
+}
+
+func (c *Collector) Describe(descs chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(descs)
+	}
+}
+
+func (c *Collector) Collect(metrics chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(metrics)
+	}
+}
@@ -0,0 +1,183 @@
+package ratecontrol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Observation is the feedback gathered between two rate adjustments.
+type Observation struct {
+	// SealingLatency is the mean latency from submission to sealing
+	// of the transactions sealed since the last adjustment.
+	SealingLatency time.Duration
+	// Sealed is the number of transactions sealed since the last adjustment.
+	Sealed int
+	// Pending is the number of submitted transactions not yet sealed or expired.
+	Pending int
+	// Submitted is the number of submission attempts since the last adjustment.
+	Submitted int
+	// SubmissionErrors is the number of submission attempts that were rejected,
+	// e.g. by the rate limiter of the access node.
+	SubmissionErrors int
+}
+
+// DefaultMaxErrorRatio is the MaxErrorRatio used if the config does not set one.
+const DefaultMaxErrorRatio = 0.05
+
+type Config struct {
+	// InitialRate, MinRate and MaxRate are submission rates in transactions per second.
+	InitialRate float64
+	MinRate     float64
+	MaxRate     float64
+
+	// AdditiveIncrease is added to the rate after an adjustment interval without congestion.
+	AdditiveIncrease float64
+	// MultiplicativeDecrease multiplies the rate after an adjustment interval with congestion.
+	// It must be between 0 and 1.
+	MultiplicativeDecrease float64
+
+	// TargetLatency is the sealing latency above which the network is considered congested.
+	// Zero disables the latency signal.
+	TargetLatency time.Duration
+	// MaxPending is the number of pending transactions above which the network is considered congested.
+	// Zero disables the pending signal.
+	MaxPending int
+	// MaxErrorRatio is the ratio of rejected submissions above which the access node is considered congested.
+	// It must be between 0 and 1, where 1 disables the error signal. Zero defaults to DefaultMaxErrorRatio.
+	MaxErrorRatio float64
+
+	// AdjustInterval is the interval between two adjustments in Run.
+	AdjustInterval time.Duration
+}
+
+func (c Config) validate() error {
+	if c.MinRate <= 0 || c.MaxRate < c.MinRate {
+		return fmt.Errorf("invalid rate bounds: min %f, max %f", c.MinRate, c.MaxRate)
+	}
+	if c.InitialRate < c.MinRate || c.InitialRate > c.MaxRate {
+		return fmt.Errorf("initial rate %f is not between %f and %f", c.InitialRate, c.MinRate, c.MaxRate)
+	}
+	if c.MultiplicativeDecrease <= 0 || c.MultiplicativeDecrease >= 1 {
+		return fmt.Errorf("multiplicative decrease %f is not between 0 and 1", c.MultiplicativeDecrease)
+	}
+	if c.MaxErrorRatio < 0 || c.MaxErrorRatio > 1 {
+		return fmt.Errorf("max error ratio %f is not between 0 and 1", c.MaxErrorRatio)
+	}
+	if c.AdjustInterval <= 0 {
+		return fmt.Errorf("adjust interval must be positive")
+	}
+	return nil
+}
+
+// Controller adjusts the submission rate of the load generator using
+// additive-increase/multiplicative-decrease (AIMD) on congestion signals:
+// sealing latency, pending transactions and submission errors.
+// It also paces submissions at the current rate.
+type Controller struct {
+	config Config
+
+	mu   sync.Mutex
+	rate float64
+	next time.Time
+}
+
+func NewController(config Config) (*Controller, error) {
+	err := config.validate()
+	if err != nil {
+		return nil, err
+	}
+	if config.MaxErrorRatio == 0 {
+		config.MaxErrorRatio = DefaultMaxErrorRatio
+	}
+
+	return &Controller{
+		config: config,
+		rate:   config.InitialRate,
+	}, nil
+}
+
+// Rate returns the current submission rate in transactions per second.
+func (c *Controller) Rate() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rate
+}
+
+// Congested reports whether the observation indicates congestion.
+func (c *Controller) Congested(observation Observation) bool {
+	if c.config.TargetLatency > 0 &&
+		observation.Sealed > 0 &&
+		observation.SealingLatency > c.config.TargetLatency {
+		return true
+	}
+	if c.config.MaxPending > 0 && observation.Pending > c.config.MaxPending {
+		return true
+	}
+	if observation.Submitted > 0 &&
+		float64(observation.SubmissionErrors)/float64(observation.Submitted) > c.config.MaxErrorRatio {
+		return true
+	}
+	return false
+}
+
+// Adjust updates the rate based on the observation and returns the new rate.
+func (c *Controller) Adjust(observation Observation) float64 {
+	congested := c.Congested(observation)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if congested {
+		c.rate *= c.config.MultiplicativeDecrease
+	} else {
+		c.rate += c.config.AdditiveIncrease
+	}
+	c.rate = max(c.config.MinRate, min(c.config.MaxRate, c.rate))
+	return c.rate
+}
+
+// Wait blocks until the next transaction may be submitted at the current rate, or ctx is done.
+func (c *Controller) Wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	if c.next.Before(now) {
+		c.next = now
+	}
+	slot := c.next
+	c.next = c.next.Add(time.Duration(float64(time.Second) / c.rate))
+	c.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run adjusts the rate every adjust interval using the signals gathered since
+// the previous adjustment, until ctx is done.
+func (c *Controller) Run(ctx context.Context, signals *Signals) error {
+	ticker := time.NewTicker(c.config.AdjustInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Adjust(signals.Observe())
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
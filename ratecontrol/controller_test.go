@@ -0,0 +1,147 @@
+package ratecontrol
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// TestControllerConvergesToCapacity drives the controller against the simulated access API
+// with a simulated clock, and checks that the AIMD rate oscillates around the network's capacity.
+func TestControllerConvergesToCapacity(t *testing.T) {
+	const capacity = 100.0
+
+	simulator, err := NewSimulatedAccessAPI(SimulationConfig{
+		Capacity:    capacity,
+		BaseLatency: 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := time.Unix(0, 0)
+	simulator.now = func() time.Time {
+		return clock
+	}
+
+	controller, err := NewController(Config{
+		InitialRate:            10,
+		MinRate:                1,
+		MaxRate:                1000,
+		AdditiveIncrease:       5,
+		MultiplicativeDecrease: 0.8,
+		TargetLatency:          3 * time.Second,
+		MaxPending:             250,
+		AdjustInterval:         time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signals := NewSignals(simulator.Pending)
+	ctx := context.Background()
+
+	pending := map[flow.Identifier]time.Time{}
+	nonce := 0
+
+	const intervals = 300
+	var rates []float64
+
+	for interval := 0; interval < intervals; interval++ {
+		// submit at the current rate, evenly spread over the interval
+		count := int(controller.Rate())
+		step := time.Second / time.Duration(count)
+		for i := 0; i < count; i++ {
+			clock = clock.Add(step)
+
+			nonce++
+			tx := flow.NewTransaction().SetScript([]byte(fmt.Sprintf("// %d", nonce)))
+			err := simulator.SendTransaction(ctx, *tx)
+			signals.Submitted(err)
+			if err == nil {
+				pending[tx.ID()] = clock
+			}
+		}
+		clock = clock.Add(time.Second - step*time.Duration(count))
+
+		// poll the results of all pending transactions, as the tracker does
+		for txID, submittedAt := range pending {
+			result, err := simulator.GetTransactionResult(ctx, txID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Status == flow.TransactionStatusSealed || result.Status == flow.TransactionStatusExpired {
+				signals.Result("", result, clock.Sub(submittedAt))
+				delete(pending, txID)
+			}
+		}
+
+		rates = append(rates, controller.Adjust(signals.Observe()))
+	}
+
+	// ignore the initial ramp up
+	steady := rates[intervals/2:]
+
+	var sum float64
+	for _, rate := range steady {
+		sum += rate
+	}
+	mean := sum / float64(len(steady))
+
+	if mean < 0.75*capacity || mean > 1.1*capacity {
+		t.Errorf("mean rate %.1f did not converge to capacity %.1f", mean, capacity)
+	}
+	for _, rate := range steady {
+		if rate > 1.3*capacity {
+			t.Errorf("rate %.1f overshoots capacity %.1f", rate, capacity)
+		}
+	}
+
+	if len(simulator.transactions) != len(pending) {
+		t.Errorf("simulator retains %d transactions, %d are pending", len(simulator.transactions), len(pending))
+	}
+}
+
+func TestControllerMaxErrorRatio(t *testing.T) {
+	config := Config{
+		InitialRate:            10,
+		MinRate:                1,
+		MaxRate:                1000,
+		AdditiveIncrease:       5,
+		MultiplicativeDecrease: 0.8,
+		AdjustInterval:         time.Second,
+	}
+
+	for _, ratio := range []float64{-0.1, 1.1} {
+		invalid := config
+		invalid.MaxErrorRatio = ratio
+		_, err := NewController(invalid)
+		if err == nil {
+			t.Errorf("expected error for max error ratio %f", ratio)
+		}
+	}
+
+	controller, err := NewController(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if controller.Congested(Observation{Submitted: 100, SubmissionErrors: 5}) {
+		t.Error("error ratio of the default is considered congested")
+	}
+	if !controller.Congested(Observation{Submitted: 100, SubmissionErrors: 6}) {
+		t.Error("error ratio above the default is not considered congested")
+	}
+
+	disabled := config
+	disabled.MaxErrorRatio = 1
+	controller, err = NewController(disabled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if controller.Congested(Observation{Submitted: 100, SubmissionErrors: 100}) {
+		t.Error("error signal is not disabled")
+	}
+}
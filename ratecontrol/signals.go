@@ -0,0 +1,79 @@
+package ratecontrol
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-standard-transactions/transactions"
+)
+
+// Signals accumulates the feedback of a load generation run between two rate adjustments.
+type Signals struct {
+	pending func() int
+
+	mu               sync.Mutex
+	submitted        int
+	submissionErrors int
+	sealed           int
+	latencySum       time.Duration
+}
+
+// NewSignals returns signals that report the number of pending transactions
+// using pending, e.g. tracker.Tracker.Pending.
+func NewSignals(pending func() int) *Signals {
+	return &Signals{
+		pending: pending,
+	}
+}
+
+// Submitted records a submission attempt and its error, if any.
+func (s *Signals) Submitted(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.submitted++
+	if err != nil {
+		s.submissionErrors++
+	}
+}
+
+// Result records the final result of a transaction.
+// It can be registered with tracker.Tracker.OnResult.
+func (s *Signals) Result(_ transactions.Label, result *flow.TransactionResult, latency time.Duration) {
+	if result.Status != flow.TransactionStatusSealed {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sealed++
+	s.latencySum += latency
+}
+
+// Observe returns the feedback gathered since the previous call and resets it.
+func (s *Signals) Observe() Observation {
+	pending := s.pending()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	observation := Observation{
+		Sealed:           s.sealed,
+		Pending:          pending,
+		Submitted:        s.submitted,
+		SubmissionErrors: s.submissionErrors,
+	}
+	if s.sealed > 0 {
+		observation.SealingLatency = s.latencySum / time.Duration(s.sealed)
+	}
+
+	s.submitted = 0
+	s.submissionErrors = 0
+	s.sealed = 0
+	s.latencySum = 0
+
+	return observation
+}
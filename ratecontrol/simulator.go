@@ -0,0 +1,172 @@
+package ratecontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ErrRateLimited is returned by the simulated access API when it rejects a submission.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+type SimulationConfig struct {
+	// Capacity is the number of transactions per second the simulated network can seal.
+	// Submissions above capacity queue up and their latency grows.
+	Capacity float64
+	// BaseLatency is the latency from submission to sealing on an idle network.
+	BaseLatency time.Duration
+	// MaxPending is the number of pending transactions above which submissions are rejected
+	// with ErrRateLimited. Zero disables rejections.
+	MaxPending int
+	// Expiry is the queueing delay after which a transaction expires instead of being sealed.
+	// Zero disables expiry.
+	Expiry time.Duration
+}
+
+func (c SimulationConfig) validate() error {
+	if c.Capacity <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
+	if c.BaseLatency < 0 {
+		return fmt.Errorf("base latency must not be negative")
+	}
+	if c.MaxPending < 0 {
+		return fmt.Errorf("max pending must not be negative")
+	}
+	if c.Expiry < 0 {
+		return fmt.Errorf("expiry must not be negative")
+	}
+	return nil
+}
+
+type simulatedTransaction struct {
+	submittedAt time.Time
+	sealedAt    time.Time
+	expired     bool
+}
+
+// SimulatedAccessAPI is an in-memory access API with configurable capacity and latency,
+// used to exercise the controller without a network.
+// It implements the SendTransaction and GetTransactionResult methods of access.Client.
+//
+// A transaction is forgotten once its final result, sealed or expired, was returned by GetTransactionResult,
+// so the memory used by a run is bounded by the transactions whose final result was not requested yet.
+type SimulatedAccessAPI struct {
+	config SimulationConfig
+	// now returns the current time; tests replace it with a simulated clock
+	now func() time.Time
+
+	mu           sync.Mutex
+	transactions map[flow.Identifier]*simulatedTransaction
+	// sealing holds the sealing times of the transactions that will be sealed, in ascending order,
+	// including the ones already sealed since the last call to pending
+	sealing  []time.Time
+	nextSlot time.Time
+}
+
+func NewSimulatedAccessAPI(config SimulationConfig) (*SimulatedAccessAPI, error) {
+	err := config.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulatedAccessAPI{
+		config:       config,
+		now:          time.Now,
+		transactions: map[flow.Identifier]*simulatedTransaction{},
+	}, nil
+}
+
+func (s *SimulatedAccessAPI) SendTransaction(_ context.Context, tx flow.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.config.MaxPending > 0 && s.pending(now) >= s.config.MaxPending {
+		return ErrRateLimited
+	}
+
+	if s.nextSlot.Before(now) {
+		s.nextSlot = now
+	}
+	queued := s.nextSlot.Sub(now)
+	s.nextSlot = s.nextSlot.Add(time.Duration(float64(time.Second) / s.config.Capacity))
+
+	simulated := &simulatedTransaction{
+		submittedAt: now,
+		sealedAt:    now.Add(queued + s.config.BaseLatency),
+		expired:     s.config.Expiry > 0 && queued > s.config.Expiry,
+	}
+	s.transactions[tx.ID()] = simulated
+	if !simulated.expired {
+		// slots are handed out in order, so sealing times are ascending
+		s.sealing = append(s.sealing, simulated.sealedAt)
+	}
+	return nil
+}
+
+func (s *SimulatedAccessAPI) GetTransactionResult(_ context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[txID]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found", txID)
+	}
+
+	status := tx.status(s.now())
+	if status == flow.TransactionStatusSealed || status == flow.TransactionStatusExpired {
+		delete(s.transactions, txID)
+	}
+
+	result := &flow.TransactionResult{
+		TransactionID: txID,
+		Status:        status,
+	}
+	return result, nil
+}
+
+// Pending returns the number of transactions that are neither sealed nor expired.
+func (s *SimulatedAccessAPI) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pending(s.now())
+}
+
+// pending drops the sealing times that have passed and returns the number of remaining ones.
+func (s *SimulatedAccessAPI) pending(now time.Time) int {
+	sealed := 0
+	for sealed < len(s.sealing) && !s.sealing[sealed].After(now) {
+		sealed++
+	}
+	s.sealing = s.sealing[sealed:]
+	return len(s.sealing)
+}
+
+func (t *simulatedTransaction) status(now time.Time) flow.TransactionStatus {
+	if t.expired {
+		if now.Before(t.sealedAt) {
+			return flow.TransactionStatusPending
+		}
+		return flow.TransactionStatusExpired
+	}
+
+	// the transaction is finalized, executed and sealed at thirds of its total latency
+	elapsed := now.Sub(t.submittedAt)
+	total := t.sealedAt.Sub(t.submittedAt)
+	switch {
+	case elapsed >= total:
+		return flow.TransactionStatusSealed
+	case elapsed >= total*2/3:
+		return flow.TransactionStatusExecuted
+	case elapsed >= total/3:
+		return flow.TransactionStatusFinalized
+	default:
+		return flow.TransactionStatusPending
+	}
+}
@@ -0,0 +1,469 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/onflow/flow-standard-transactions/transactions"
+)
+
+// Client is the subset of the access API used to follow transactions.
+// access.Client satisfies it.
+type Client interface {
+	GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error)
+}
+
+// Stage is a lifecycle stage of a transaction after it was submitted.
+type Stage string
+
+const (
+	StageFinalized Stage = "finalized"
+	StageExecuted  Stage = "executed"
+	StageSealed    Stage = "sealed"
+)
+
+var stages = []Stage{StageFinalized, StageExecuted, StageSealed}
+
+func stageOf(status flow.TransactionStatus) int {
+	switch status {
+	case flow.TransactionStatusFinalized:
+		return 1
+	case flow.TransactionStatusExecuted:
+		return 2
+	case flow.TransactionStatusSealed:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type trackedTransaction struct {
+	label       transactions.Label
+	submittedAt time.Time
+	// reached is the number of stages already recorded
+	reached int
+	// pollErrors is the number of consecutive failed result requests
+	pollErrors int
+}
+
+const (
+	// DefaultResultTimeout is longer than the expiry of transactions, about 600 blocks,
+	// so transactions that are still found time out only if block production stalls.
+	DefaultResultTimeout = 15 * time.Minute
+	// DefaultMaxPollErrors is the number of consecutive failed result requests after which
+	// a transaction is given up, e.g. because the access node never received it.
+	DefaultMaxPollErrors = 30
+
+	// reservoirSize is the number of latencies kept per label and stage to estimate percentiles.
+	reservoirSize = 10_000
+)
+
+// ErrNoResult is the error of the results reported for transactions that were given up,
+// as their result could not be obtained within the result timeout or the maximum number of failed polls.
+var ErrNoResult = errors.New("no transaction result")
+
+// Tracker follows submitted transactions through their status changes by polling
+// and records, per label, the latency from submission to each stage.
+// Latencies are measured at poll granularity: a stage is recorded when a poll first
+// observes it, and stages skipped between two polls get the same latency.
+// Percentiles are estimated from a uniform sample of at most reservoirSize latencies per label and stage,
+// so memory stays bounded on long runs.
+type Tracker struct {
+	client        Client
+	pollInterval  time.Duration
+	resultTimeout time.Duration
+	maxPollErrors int
+
+	mu        sync.Mutex
+	pending   map[flow.Identifier]*trackedTransaction
+	latencies map[transactions.Label]map[Stage]*latencySample
+	expired   map[transactions.Label]uint64
+	noResult  map[transactions.Label]uint64
+	// pollErrors is the number of failed result requests
+	pollErrors uint64
+
+	resultHandlers []ResultHandler
+
+	histogram        *prometheus.HistogramVec
+	pollErrorCounter prometheus.Counter
+}
+
+var _ prometheus.Collector = (*Tracker)(nil)
+
+func New(client Client, pollInterval time.Duration) *Tracker {
+	return &Tracker{
+		client:        client,
+		pollInterval:  pollInterval,
+		resultTimeout: DefaultResultTimeout,
+		maxPollErrors: DefaultMaxPollErrors,
+		pending:       map[flow.Identifier]*trackedTransaction{},
+		latencies:     map[transactions.Label]map[Stage]*latencySample{},
+		expired:       map[transactions.Label]uint64{},
+		noResult:      map[transactions.Label]uint64{},
+		histogram: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "transaction_lifecycle_latency_seconds",
+				Help:    "Latency from submission of a transaction to reaching a lifecycle stage.",
+				Buckets: prometheus.ExponentialBuckets(0.25, 2, 10),
+			},
+			[]string{"label", "stage"},
+		),
+		pollErrorCounter: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "transaction_result_poll_errors_total",
+				Help: "Number of failed requests for the result of a tracked transaction.",
+			},
+		),
+	}
+}
+
+// SetResultTimeout sets the time after submission at which a transaction that is not sealed
+// or expired is given up. Zero disables the timeout. Defaults to DefaultResultTimeout.
+func (t *Tracker) SetResultTimeout(timeout time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.resultTimeout = timeout
+}
+
+// SetMaxPollErrors sets the number of consecutive failed result requests after which
+// a transaction is given up. Zero disables the limit. Defaults to DefaultMaxPollErrors.
+func (t *Tracker) SetMaxPollErrors(maxPollErrors int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.maxPollErrors = maxPollErrors
+}
+
+// ResultHandler is called with the final result of a tracked transaction,
+// once it is sealed or expired, and the latency from submission to that point.
+// Transactions that are given up are reported with a result of status unknown,
+// whose error wraps ErrNoResult.
+type ResultHandler func(label transactions.Label, result *flow.TransactionResult, latency time.Duration)
+
+// OnResult registers a handler for final transaction results.
+// Handlers are called synchronously from Run and must not call back into the tracker.
+func (t *Tracker) OnResult(handler ResultHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.resultHandlers = append(t.resultHandlers, handler)
+}
+
+// Track starts following a transaction that was submitted at submittedAt.
+func (t *Tracker) Track(label transactions.Label, txID flow.Identifier, submittedAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending[txID] = &trackedTransaction{
+		label:       label,
+		submittedAt: submittedAt,
+	}
+}
+
+// Pending returns the number of tracked transactions that are not yet sealed, expired or given up.
+func (t *Tracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.pending)
+}
+
+// PollErrors returns the number of failed requests for transaction results.
+func (t *Tracker) PollErrors() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.pollErrors
+}
+
+// Run polls the status of all pending transactions until ctx is done.
+// A failed request for a result is counted, see PollErrors,
+// and the transaction stays pending, so it is polled again on the next tick.
+// Errors are expected on long runs, e.g. transient RPC errors,
+// or results that are not found yet right after submission.
+// Transactions are given up once the result timeout passes or the requests for their result
+// fail too often in a row, see SetResultTimeout and SetMaxPollErrors.
+func (t *Tracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.poll(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (t *Tracker) poll(ctx context.Context) {
+	t.mu.Lock()
+	txIDs := make([]flow.Identifier, 0, len(t.pending))
+	for txID := range t.pending {
+		txIDs = append(txIDs, txID)
+	}
+	t.mu.Unlock()
+
+	for _, txID := range txIDs {
+		result, err := t.client.GetTransactionResult(ctx, txID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			t.pollErrorCounter.Inc()
+			t.pollFailed(txID, err, time.Now())
+			continue
+		}
+		t.observe(txID, result, time.Now())
+	}
+}
+
+func (t *Tracker) pollFailed(txID flow.Identifier, err error, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pollErrors++
+
+	tx, ok := t.pending[txID]
+	if !ok {
+		return
+	}
+	tx.pollErrors++
+	if t.maxPollErrors > 0 && tx.pollErrors >= t.maxPollErrors {
+		t.giveUp(txID, tx, fmt.Errorf("%w: %d requests failed, last: %w", ErrNoResult, tx.pollErrors, err), now)
+		return
+	}
+	t.checkTimeout(txID, tx, now)
+}
+
+// checkTimeout gives up the transaction if the result timeout has passed. It reports whether it did.
+func (t *Tracker) checkTimeout(txID flow.Identifier, tx *trackedTransaction, now time.Time) bool {
+	if t.resultTimeout <= 0 || now.Sub(tx.submittedAt) < t.resultTimeout {
+		return false
+	}
+	t.giveUp(txID, tx, fmt.Errorf("%w: not sealed within %s", ErrNoResult, t.resultTimeout), now)
+	return true
+}
+
+// giveUp stops tracking the transaction and reports it as failed with err.
+func (t *Tracker) giveUp(txID flow.Identifier, tx *trackedTransaction, err error, now time.Time) {
+	t.noResult[tx.label]++
+	delete(t.pending, txID)
+	result := &flow.TransactionResult{
+		Status:        flow.TransactionStatusUnknown,
+		Error:         err,
+		TransactionID: txID,
+	}
+	t.handleResult(tx.label, result, now.Sub(tx.submittedAt))
+}
+
+func (t *Tracker) observe(txID flow.Identifier, result *flow.TransactionResult, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tx, ok := t.pending[txID]
+	if !ok {
+		return
+	}
+
+	latency := now.Sub(tx.submittedAt)
+	tx.pollErrors = 0
+
+	if result.Status == flow.TransactionStatusExpired {
+		t.expired[tx.label]++
+		delete(t.pending, txID)
+		t.handleResult(tx.label, result, latency)
+		return
+	}
+
+	reached := stageOf(result.Status)
+	for ; tx.reached < reached; tx.reached++ {
+		stage := stages[tx.reached]
+		t.record(tx.label, stage, latency)
+	}
+
+	if tx.reached == len(stages) {
+		delete(t.pending, txID)
+		t.handleResult(tx.label, result, latency)
+		return
+	}
+	t.checkTimeout(txID, tx, now)
+}
+
+func (t *Tracker) handleResult(label transactions.Label, result *flow.TransactionResult, latency time.Duration) {
+	for _, handler := range t.resultHandlers {
+		handler(label, result, latency)
+	}
+}
+
+func (t *Tracker) record(label transactions.Label, stage Stage, latency time.Duration) {
+	byStage, ok := t.latencies[label]
+	if !ok {
+		byStage = map[Stage]*latencySample{}
+		t.latencies[label] = byStage
+	}
+	sample, ok := byStage[stage]
+	if !ok {
+		sample = &latencySample{}
+		byStage[stage] = sample
+	}
+	sample.add(latency)
+
+	t.histogram.WithLabelValues(label, string(stage)).Observe(latency.Seconds())
+}
+
+// Distribution summarizes the latencies recorded for one label and stage.
+type Distribution struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+}
+
+// latencySample keeps the exact count, sum, minimum and maximum of the recorded latencies,
+// and a uniform random sample of at most reservoirSize of them, see reservoir sampling.
+type latencySample struct {
+	count     int
+	sum       time.Duration
+	min       time.Duration
+	max       time.Duration
+	reservoir []time.Duration
+}
+
+func (s *latencySample) add(latency time.Duration) {
+	s.count++
+	s.sum += latency
+	if s.count == 1 || latency < s.min {
+		s.min = latency
+	}
+	if latency > s.max {
+		s.max = latency
+	}
+
+	if len(s.reservoir) < reservoirSize {
+		s.reservoir = append(s.reservoir, latency)
+		return
+	}
+	// the latency replaces a sampled one with probability reservoirSize/count
+	if i := rand.IntN(s.count); i < reservoirSize {
+		s.reservoir[i] = latency
+	}
+}
+
+func newDistribution(sample *latencySample) Distribution {
+	sorted := make([]time.Duration, len(sample.reservoir))
+	copy(sorted, sample.reservoir)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+
+	return Distribution{
+		Count: sample.count,
+		Min:   sample.min,
+		Mean:  sample.sum / time.Duration(sample.count),
+		P50:   percentile(0.50),
+		P90:   percentile(0.90),
+		P99:   percentile(0.99),
+		Max:   sample.max,
+	}
+}
+
+// LabelReport holds the latency distributions of one label.
+// NoResult is the number of transactions that were given up.
+type LabelReport struct {
+	Stages   map[Stage]Distribution `json:"stages"`
+	Expired  uint64                 `json:"expired"`
+	NoResult uint64                 `json:"noResult"`
+}
+
+// Report returns the latency distributions of all labels recorded so far.
+func (t *Tracker) Report() map[transactions.Label]LabelReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := map[transactions.Label]LabelReport{}
+	reportOf := func(label transactions.Label) LabelReport {
+		labelReport, ok := report[label]
+		if !ok {
+			labelReport = LabelReport{
+				Stages: map[Stage]Distribution{},
+			}
+		}
+		return labelReport
+	}
+
+	for label, byStage := range t.latencies {
+		labelReport := reportOf(label)
+		for stage, sample := range byStage {
+			labelReport.Stages[stage] = newDistribution(sample)
+		}
+		report[label] = labelReport
+	}
+	for label, expired := range t.expired {
+		labelReport := reportOf(label)
+		labelReport.Expired = expired
+		report[label] = labelReport
+	}
+	for label, noResult := range t.noResult {
+		labelReport := reportOf(label)
+		labelReport.NoResult = noResult
+		report[label] = labelReport
+	}
+	return report
+}
+
+// WriteJSON writes the report to w as JSON. Durations are encoded in nanoseconds.
+func (t *Tracker) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t.Report())
+}
+
+// Handler serves the latency histograms in Prometheus format on /metrics
+// and the report as JSON on /latencies.
+func (t *Tracker) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(t)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/latencies", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		err := t.WriteJSON(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	return mux
+}
+
+func (t *Tracker) Describe(descs chan<- *prometheus.Desc) {
+	t.histogram.Describe(descs)
+	t.pollErrorCounter.Describe(descs)
+}
+
+func (t *Tracker) Collect(metrics chan<- prometheus.Metric) {
+	t.histogram.Collect(metrics)
+	t.pollErrorCounter.Collect(metrics)
+}
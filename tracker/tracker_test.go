@@ -0,0 +1,91 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-standard-transactions/transactions"
+)
+
+type fakeClient struct {
+	results map[flow.Identifier]*flow.TransactionResult
+}
+
+func (c *fakeClient) GetTransactionResult(_ context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	result, ok := c.results[txID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return result, nil
+}
+
+func TestTrackerGivesUp(t *testing.T) {
+	lost := flow.Identifier{1}
+	stuck := flow.Identifier{2}
+	client := &fakeClient{
+		results: map[flow.Identifier]*flow.TransactionResult{
+			stuck: {Status: flow.TransactionStatusPending},
+		},
+	}
+
+	tracker := New(client, time.Second)
+	tracker.SetMaxPollErrors(3)
+	tracker.SetResultTimeout(time.Minute)
+
+	given := map[flow.Identifier]error{}
+	tracker.OnResult(func(_ transactions.Label, result *flow.TransactionResult, _ time.Duration) {
+		if result.Status != flow.TransactionStatusUnknown {
+			t.Errorf("unexpected status %s", result.Status)
+		}
+		given[result.TransactionID] = result.Error
+	})
+
+	tracker.Track("Lost", lost, time.Now())
+	tracker.Track("Stuck", stuck, time.Now().Add(-2*time.Minute))
+
+	for range 3 {
+		tracker.poll(context.Background())
+	}
+
+	if tracker.Pending() != 0 {
+		t.Errorf("%d transactions are still pending", tracker.Pending())
+	}
+	for _, txID := range []flow.Identifier{lost, stuck} {
+		if !errors.Is(given[txID], ErrNoResult) {
+			t.Errorf("transaction %s was reported with error %v", txID, given[txID])
+		}
+	}
+
+	report := tracker.Report()
+	if report["Lost"].NoResult != 1 || report["Stuck"].NoResult != 1 {
+		t.Errorf("unexpected report %v", report)
+	}
+}
+
+func TestLatencySampleIsBounded(t *testing.T) {
+	sample := &latencySample{}
+	const count = 3 * reservoirSize
+	for i := 1; i <= count; i++ {
+		sample.add(time.Duration(i) * time.Millisecond)
+	}
+
+	if len(sample.reservoir) != reservoirSize {
+		t.Errorf("reservoir holds %d latencies, expected %d", len(sample.reservoir), reservoirSize)
+	}
+
+	distribution := newDistribution(sample)
+	if distribution.Count != count ||
+		distribution.Min != time.Millisecond ||
+		distribution.Max != count*time.Millisecond {
+		t.Errorf("unexpected distribution %+v", distribution)
+	}
+	// the median of the uniform sample is close to the median of all latencies
+	median := count / 2 * time.Millisecond
+	if distribution.P50 < median*9/10 || distribution.P50 > median*11/10 {
+		t.Errorf("median %s is far from %s", distribution.P50, median)
+	}
+}
@@ -0,0 +1,151 @@
+package transactions
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ACCOUNT LINKING TRANSACTIONS
+
+// The account linking transactions are run by two parties, as in hybrid custody:
+// a child account links itself by publishing an account capability to the inbox of a parent account,
+// which claims it into its TestContract.ChildAccountManager and then acts on the child through it.
+const (
+	childAccountCapabilityName = "AStChildAccount"
+	childAccountManagerPath    = "/storage/AStChildAccountManager"
+	childAccountValuePath      = "/storage/AStChildValue"
+)
+
+// childAccountManager borrows the parent's child account manager.
+var childAccountManager = fmt.Sprintf(
+	`
+		let manager = signer.storage.borrow<auth(TestContract.ManageChildren) &TestContract.ChildAccountManager>(
+			from: %s
+		) ?? panic("signer has no child account manager")
+	`,
+	childAccountManagerPath,
+)
+
+// LinkChildAccountTransaction issues an account capability to the signer's account
+// and publishes it to the inbox of parent. The controller is tagged, so RevokeChildAccountTransaction can find it.
+var LinkChildAccountTransaction = func(parent flow.Address) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				let cap = signer.capabilities.account.issue<auth(Storage) &Account>()
+				signer.capabilities.account.getController(byCapabilityID: cap.id)!.setTag("%[1]s")
+				signer.inbox.publish(cap, name: "%[1]s", recipient: %[2]s)
+			`,
+			childAccountCapabilityName,
+			parent.HexWithPrefix(),
+		),
+	).SetAuthorizers(Signer(
+		EntitlementIssueAccountCapabilityController,
+		EntitlementGetAccountCapabilityController,
+		EntitlementPublishInboxCapability,
+	)).SetExpectedEvents(
+		ExpectedEvent{Type: EventAccountCapabilityControllerIssued, Count: 1},
+		ExpectedEvent{Type: EventInboxValuePublished, Count: 1},
+	)
+}
+
+// ClaimChildAccountTransaction claims the account capability published by child
+// with LinkChildAccountTransaction and adds it to the signer's child account manager,
+// which is created on first use.
+var ClaimChildAccountTransaction = func(child flow.Address) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				if signer.storage.type(at: %[1]s) == nil {
+					signer.storage.save(<- TestContract.createChildAccountManager(), to: %[1]s)
+				}
+				%[2]s
+				let cap = signer.inbox.claim<auth(Storage) &Account>("%[3]s", provider: %[4]s)
+					?? panic("child has not published an account capability to signer")
+				manager.addChild(cap)
+			`,
+			childAccountManagerPath,
+			childAccountManager,
+			childAccountCapabilityName,
+			child.HexWithPrefix(),
+		),
+	).SetAuthorizers(Signer(
+		EntitlementSaveValue,
+		EntitlementBorrowValue,
+		EntitlementClaimInboxCapability,
+	)).SetExpectedEvents(ExpectedEvent{Type: EventInboxValueClaimed, Count: 1})
+}
+
+// ChildAccountActionTransaction borrows child from the signer's child account manager
+// and saves and loads a value in the child's storage, loopLength times.
+var ChildAccountActionTransaction = func(loopLength uint64, child flow.Address) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				%s
+				let child = manager.borrowChild(%s)
+				%s
+			`,
+			childAccountManager,
+			child.HexWithPrefix(),
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(
+					`
+						child.storage.save(i, to: %[1]s)
+						child.storage.load<Int>(from: %[1]s)
+					`,
+					childAccountValuePath,
+				),
+			),
+		),
+	).SetAuthorizers(Signer(EntitlementBorrowValue))
+}
+
+// RevokeChildAccountTransaction deletes the controllers of the account capabilities
+// issued by LinkChildAccountTransaction, so parents can no longer borrow the signer's account.
+var RevokeChildAccountTransaction = NewSimpleTransaction(
+	fmt.Sprintf(
+		`
+			for controller in signer.capabilities.account.getControllers() {
+				if controller.tag == "%s" {
+					controller.delete()
+				}
+			}
+		`,
+		childAccountCapabilityName,
+	),
+).SetAuthorizers(Signer(EntitlementGetAccountCapabilityController))
+
+// RemoveChildAccountTransaction removes child from the signer's child account manager.
+var RemoveChildAccountTransaction = func(child flow.Address) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				%s
+				manager.removeChild(%s)
+			`,
+			childAccountManager,
+			child.HexWithPrefix(),
+		),
+	).SetAuthorizers(Signer(EntitlementBorrowValue))
+}
+
+// IssueAndRevokeAccountCapabilityTransaction issues an account capability
+// and deletes its controller, loopLength times.
+var IssueAndRevokeAccountCapabilityTransaction = func(loopLength uint64) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		`
+			let cap = signer.capabilities.account.issue<auth(Storage) &Account>()
+			signer.capabilities.account.getController(byCapabilityID: cap.id)!.delete()
+		`,
+	).SetAuthorizers(Signer(
+		EntitlementIssueAccountCapabilityController,
+		EntitlementGetAccountCapabilityController,
+	)).SetExpectedEvents(
+		ExpectedEvent{Type: EventAccountCapabilityControllerIssued, Count: loopLength},
+		ExpectedEvent{Type: EventAccountCapabilityControllerDeleted, Count: loopLength},
+	)
+}
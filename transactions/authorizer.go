@@ -0,0 +1,83 @@
+package transactions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Entitlement is an entitlement of the Cadence Account type.
+type Entitlement = string
+
+const (
+	// storage
+	EntitlementStorage     Entitlement = "Storage"
+	EntitlementSaveValue   Entitlement = "SaveValue"
+	EntitlementLoadValue   Entitlement = "LoadValue"
+	EntitlementCopyValue   Entitlement = "CopyValue"
+	EntitlementBorrowValue Entitlement = "BorrowValue"
+
+	// contracts
+	EntitlementContracts      Entitlement = "Contracts"
+	EntitlementAddContract    Entitlement = "AddContract"
+	EntitlementUpdateContract Entitlement = "UpdateContract"
+	EntitlementRemoveContract Entitlement = "RemoveContract"
+
+	// keys
+	EntitlementKeys      Entitlement = "Keys"
+	EntitlementAddKey    Entitlement = "AddKey"
+	EntitlementRevokeKey Entitlement = "RevokeKey"
+
+	// inbox
+	EntitlementInbox                    Entitlement = "Inbox"
+	EntitlementPublishInboxCapability   Entitlement = "PublishInboxCapability"
+	EntitlementUnpublishInboxCapability Entitlement = "UnpublishInboxCapability"
+	EntitlementClaimInboxCapability     Entitlement = "ClaimInboxCapability"
+
+	// capabilities
+	EntitlementCapabilities                     Entitlement = "Capabilities"
+	EntitlementStorageCapabilities              Entitlement = "StorageCapabilities"
+	EntitlementAccountCapabilities              Entitlement = "AccountCapabilities"
+	EntitlementPublishCapability                Entitlement = "PublishCapability"
+	EntitlementUnpublishCapability              Entitlement = "UnpublishCapability"
+	EntitlementGetStorageCapabilityController   Entitlement = "GetStorageCapabilityController"
+	EntitlementIssueStorageCapabilityController Entitlement = "IssueStorageCapabilityController"
+	EntitlementGetAccountCapabilityController   Entitlement = "GetAccountCapabilityController"
+	EntitlementIssueAccountCapabilityController Entitlement = "IssueAccountCapabilityController"
+)
+
+// Authorizer is an authorizer of a transaction: a named parameter of the prepare block
+// with the entitlements the transaction needs on that account.
+type Authorizer struct {
+	Name         string
+	Entitlements []Entitlement
+}
+
+// SignerName is the name of the single authorizer most transactions have.
+const SignerName = "signer"
+
+// Signer returns the authorizer named signer with the given entitlements.
+func Signer(entitlements ...Entitlement) Authorizer {
+	return Authorizer{
+		Name:         SignerName,
+		Entitlements: entitlements,
+	}
+}
+
+// DefaultAuthorizers are used for transactions that do not declare their authorizers.
+var DefaultAuthorizers = []Authorizer{
+	Signer(
+		EntitlementStorage,
+		EntitlementKeys,
+		EntitlementContracts,
+		EntitlementCapabilities,
+	),
+}
+
+// Parameter returns the prepare block parameter of the authorizer,
+// e.g. signer: auth(BorrowValue) &Account.
+func (a Authorizer) Parameter() string {
+	if len(a.Entitlements) == 0 {
+		return fmt.Sprintf("%s: &Account", a.Name)
+	}
+	return fmt.Sprintf("%s: auth(%s) &Account", a.Name, strings.Join(a.Entitlements, ", "))
+}
@@ -0,0 +1,979 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// FuncTemplate is a Template backed by a constructor function,
+// which receives the values resolved against the schema.
+type FuncTemplate struct {
+	Schema    Parameters
+	Construct func(values Values) (*SimpleTransaction, error)
+}
+
+var _ Template = (*FuncTemplate)(nil)
+
+func (t *FuncTemplate) Parameters() Parameters {
+	return t.Schema
+}
+
+// New resolves the values and constructs the transaction.
+func (t *FuncTemplate) New(values map[string]any) (Transaction, error) {
+	resolved, err := t.Schema.Resolve(values)
+	if err != nil {
+		return nil, err
+	}
+	return t.Construct(resolved)
+}
+
+// Values are parameter values resolved against a schema.
+// The accessors panic if the value is missing or of another type,
+// which cannot happen for parameters declared in the schema.
+type Values map[string]any
+
+func (v Values) Uint64(name string) uint64 {
+	return v[name].(uint64)
+}
+
+func (v Values) Float64(name string) float64 {
+	return v[name].(float64)
+}
+
+func (v Values) String(name string) string {
+	return v[name].(string)
+}
+
+func (v Values) Strings(name string) []string {
+	return v[name].([]string)
+}
+
+// NamedTemplate is a template with the label it is registered under.
+type NamedTemplate struct {
+	Label    Label
+	Template Template
+}
+
+func bound(value float64) *float64 {
+	return &value
+}
+
+// maxLoopLength is far more iterations than any loop body fits into the computation limit of a transaction.
+const maxLoopLength = 1_000_000
+
+var loopLengthParameter = Parameter{
+	Name:        "loopLength",
+	Type:        ParameterTypeUint64,
+	Description: "Number of loop iterations.",
+	Default:     uint64(10),
+	Max:         bound(maxLoopLength),
+}
+
+func loopTemplate(label Label, constructor func(loopLength uint64) *SimpleTransaction) NamedTemplate {
+	return NamedTemplate{
+		Label: label,
+		Template: &FuncTemplate{
+			Schema: Parameters{loopLengthParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return constructor(values.Uint64("loopLength")), nil
+			},
+		},
+	}
+}
+
+// validatedLoopTemplate is loopTemplate for constructors that validate the loop length.
+func validatedLoopTemplate(
+	label Label,
+	constructor func(loopLength uint64) (*SimpleTransaction, error),
+) NamedTemplate {
+	return NamedTemplate{
+		Label: label,
+		Template: &FuncTemplate{
+			Schema: Parameters{loopLengthParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return constructor(values.Uint64("loopLength"))
+			},
+		},
+	}
+}
+
+// fixedTemplate returns a copy of tx on each construction,
+// so callers cannot modify the shared package-level transaction.
+func fixedTemplate(label Label, tx *SimpleTransaction) NamedTemplate {
+	return NamedTemplate{
+		Label: label,
+		Template: &FuncTemplate{
+			Construct: func(Values) (*SimpleTransaction, error) {
+				return tx.Copy(), nil
+			},
+		},
+	}
+}
+
+var signatureAlgorithmParameter = Parameter{
+	Name:    "signatureAlgorithm",
+	Type:    ParameterTypeString,
+	Default: crypto.ECDSA_P256.String(),
+	Enum: []string{
+		crypto.ECDSA_P256.String(),
+		crypto.ECDSA_secp256k1.String(),
+		crypto.BLS_BLS12_381.String(),
+	},
+}
+
+var hashAlgorithmParameter = Parameter{
+	Name:    "hashAlgorithm",
+	Type:    ParameterTypeString,
+	Default: crypto.SHA3_256.String(),
+	Enum: []string{
+		crypto.SHA2_256.String(),
+		crypto.SHA3_256.String(),
+		crypto.Keccak256.String(),
+	},
+}
+
+func decodePublicKeys(signatureAlgorithm string, encoded []string) ([]crypto.PublicKey, error) {
+	algorithm := crypto.StringToSignatureAlgorithm(signatureAlgorithm)
+	publicKeys := make([]crypto.PublicKey, 0, len(encoded))
+	for _, key := range encoded {
+		publicKey, err := crypto.DecodePublicKeyHex(algorithm, key)
+		if err != nil {
+			return nil, invalidParameter("failed to decode public key %s: %s", key, err)
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys, nil
+}
+
+// decodeAddress decodes a hex-encoded address, with or without 0x prefix.
+func decodeAddress(name string, encoded string) (flow.Address, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if err != nil || len(b) == 0 || len(b) > flow.AddressLength {
+		return flow.EmptyAddress, invalidParameter("%s %q is not a hex-encoded address", name, encoded)
+	}
+	return flow.BytesToAddress(b), nil
+}
+
+var recipientParameter = Parameter{
+	Name:        "recipient",
+	Type:        ParameterTypeString,
+	Description: "Hex-encoded address of the recipient.",
+}
+
+// decodeAddresses decodes hex-encoded addresses, with or without 0x prefix.
+func decodeAddresses(name string, encoded []string) ([]flow.Address, error) {
+	addresses := make([]flow.Address, 0, len(encoded))
+	for _, value := range encoded {
+		address, err := decodeAddress(name, value)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+var amountParameter = Parameter{
+	Name:        "amount",
+	Type:        ParameterTypeUint64,
+	Description: "Amount of tokens, in UFix64 units.",
+	Default:     uint64(100_000_000),
+}
+
+var childParameter = Parameter{
+	Name:        "child",
+	Type:        ParameterTypeString,
+	Description: "Hex-encoded address of the child account.",
+}
+
+var numCapabilitiesParameter = Parameter{
+	Name:        "numCapabilities",
+	Type:        ParameterTypeUint64,
+	Description: "Number of capabilities published to the inbox.",
+	Default:     uint64(10),
+	Min:         bound(1),
+}
+
+var numNFTsParameter = Parameter{
+	Name:        "numNFTs",
+	Type:        ParameterTypeUint64,
+	Description: "Number of NFTs taken from the signer's collection.",
+	Default:     uint64(10),
+	Min:         bound(1),
+}
+
+// BuiltinTemplates are the templates of the transaction constructors of this package,
+// labelled with the constructor name without the Transaction suffix.
+var BuiltinTemplates = []NamedTemplate{
+	loopTemplate("EmptyLoop", EmptyLoopTransaction),
+	loopTemplate("AssertTrue", AssertTrueTransaction),
+	loopTemplate("GetSignerAddress", GetSignerAddressTransaction),
+	loopTemplate("GetSignerPublicAccount", GetSignerPublicAccountTransaction),
+	loopTemplate("GetSignerAccountBalance", GetSignerAccountBalanceTransaction),
+	loopTemplate("GetSignerAccountAvailableBalance", GetSignerAccountAvailableBalanceTransaction),
+	loopTemplate("GetSignerAccountStorageUsed", GetSignerAccountStorageUsedTransaction),
+	loopTemplate("GetSignerAccountStorageCapacity", GetSignerAccountStorageCapacityTransaction),
+	loopTemplate("BorrowSignerAccountFlowTokenVault", BorrowSignerAccountFlowTokenVaultTransaction),
+	loopTemplate("BorrowSignerAccountFungibleTokenReceiver", BorrowSignerAccountFungibleTokenReceiverTransaction),
+	loopTemplate("TransferTokensToSelf", TransferTokensToSelfTransaction),
+	loopTemplate("CreateNewAccount", CreateNewAccountTransaction),
+	loopTemplate("CreateNewAccountWithContract", CreateNewAccountWithContractTransaction),
+	loopTemplate("DecodeHex", DecodeHexTransaction),
+	loopTemplate("RevertibleRandom", RevertibleRandomTransaction),
+	loopTemplate("NumberToStringConversion", NumberToStringConversionTransaction),
+	loopTemplate("ConcatenateString", ConcatenateStringTransaction),
+	loopTemplate("AddKeyToAccount", AddKeyToAccountTransaction),
+	loopTemplate("AddAndRevokeKeyToAccount", AddAndRevokeKeyToAccountTransaction),
+	loopTemplate("GetAccountKey", GetAccountKeyTransaction),
+	loopTemplate("GetContracts", GetContractsTransaction),
+	loopTemplate("Hash", HashTransaction),
+	loopTemplate("GetCurrentBlock", GetCurrentBlockTransaction),
+	loopTemplate("GetBlockAt", GetBlockAtTransaction),
+	loopTemplate("DestroyResourceDictionary", DestroyResourceDictionaryTransaction),
+	loopTemplate("ParseUFix64", ParseUFix64Transaction),
+	loopTemplate("ParseFix64", ParseFix64Transaction),
+	loopTemplate("ParseUInt64", ParseUInt64Transaction),
+	loopTemplate("ParseInt64", ParseInt64Transaction),
+	loopTemplate("ParseInt", ParseIntTransaction),
+	loopTemplate("IssueStorageCapability", IssueStorageCapabilityTransaction),
+	loopTemplate("GetKeyCount", GetKeyCountTransaction),
+	validatedLoopTemplate("CreateKeyECDSAP256", NewCreateKeyECDSAP256Transaction),
+	loopTemplate("CreateKeyEDCSAsecp256k1", CreateKeyEDCSAsecp256k1Transaction),
+	loopTemplate("CreateKeyBLSBLS12381", CreateKeyBLSBLS12381Transaction),
+	loopTemplate("ArrayInsert", ArrayInsertTransaction),
+	loopTemplate("ArrayInsertRemove", ArrayInsertRemoveTransaction),
+	loopTemplate("ArrayInsertSetRemove", ArrayInsertSetRemoveTransaction),
+	loopTemplate("ArrayInsertMap", ArrayInsertMapTransaction),
+	loopTemplate("ArrayInsertFilter", ArrayInsertFilterTransaction),
+	loopTemplate("DictInsert", DictInsertTransaction),
+	loopTemplate("DictInsertRemove", DictInsertRemoveTransaction),
+	loopTemplate("DictInsertSetRemove", DictInsertSetRemoveTransaction),
+	loopTemplate("DictIterCopy", DictIterCopyTransaction),
+	validatedLoopTemplate("ArrayCreateBatch", NewArrayCreateBatchTransaction),
+	validatedLoopTemplate("BLSVerifyProofOfPossession", NewBLSVerifyProofOfPossessionTransaction),
+	loopTemplate("CallEmptyContractFunction", CallEmptyContractFunctionTransaction),
+	loopTemplate("EmitEvent", EmitEventTransaction),
+	loopTemplate("MintNFT", MintNFTTransaction),
+	loopTemplate("BurnMintedNFT", BurnMintedNFTTransaction),
+	loopTemplate("MintAndBurnNFT", MintAndBurnNFTTransaction),
+	loopTemplate("MintNFTToSignerStorage", MintNFTToSignerStorageTransaction),
+	fixedTemplate("ResetTestContract", ResetTestContractTransaction),
+	loopTemplate("PreCondition", PreConditionTransaction),
+	loopTemplate("PostCondition", PostConditionTransaction),
+	loopTemplate("PreConditionLoop", PreConditionLoopTransaction),
+	loopTemplate("PostConditionLoop", PostConditionLoopTransaction),
+	loopTemplate("ScheduledTransactionAndExecute", ScheduledTransactionAndExecuteTransaction),
+	fixedTemplate("BorrowString", BorrowStringTransaction),
+	fixedTemplate("CopyString", CopyStringTransaction),
+	fixedTemplate("CopyStringAndSaveADuplicate", CopyStringAndSaveADuplicateTransaction),
+	fixedTemplate("StoreLoadAndDestroyDictString", StoreLoadAndDestroyDictStringTransaction),
+	fixedTemplate("BorrowDictString", BorrowDictStringTransaction),
+	fixedTemplate("CopyDictString", CopyDictStringTransaction),
+	fixedTemplate("CopyDictStringAndSaveADuplicate", CopyDictStringAndSaveADuplicateTransaction),
+	fixedTemplate("LoadDictAndDestroyIt", LoadDictAndDestroyItTransaction),
+	resourceCollectionTemplate("CreateAndDestroyResourceCollection", NewCreateAndDestroyResourceCollectionTransaction),
+	resourceCollectionTemplate("MoveResourceCollection", NewMoveResourceCollectionTransaction),
+	resourceCollectionTemplate("SwapResourceCollectionElements", NewSwapResourceCollectionElementsTransaction),
+	{
+		Label: "SaveResourceCollection",
+		Template: &FuncTemplate{
+			Schema: resourceCollectionParameters,
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewSaveResourceCollectionTransaction(
+					ResourceCollection(values.String("collection")),
+					values.Uint64("size"),
+					values.Uint64("depth"),
+				)
+			},
+		},
+	},
+	loopTemplate("LoadAndSaveResourceCollection", LoadAndSaveResourceCollectionTransaction),
+	fixedTemplate("LoadAndDestroyResourceCollection", LoadAndDestroyResourceCollectionTransaction),
+	fixedTemplate("SetupNFTCollection", SetupNFTCollectionTransaction),
+	loopTemplate("MintNFTToCollection", MintNFTToCollectionTransaction),
+	loopTemplate("ResolveNFTViews", ResolveNFTViewsTransaction),
+	{
+		Label: "TransferNFTs",
+		Template: &FuncTemplate{
+			Schema: Parameters{recipientParameter, numNFTsParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				recipient, err := decodeAddress("recipient", values.String("recipient"))
+				if err != nil {
+					return nil, err
+				}
+				return NewTransferNFTsTransaction(recipient, values.Uint64("numNFTs"))
+			},
+		},
+	},
+	{
+		Label: "BurnNFTs",
+		Template: &FuncTemplate{
+			Schema: Parameters{numNFTsParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewBurnNFTsTransaction(values.Uint64("numNFTs"))
+			},
+		},
+	},
+	fixedTemplate("SetupTestTokenVault", SetupTestTokenVaultTransaction),
+	{
+		Label: "MintTestTokens",
+		Template: &FuncTemplate{
+			Schema: Parameters{loopLengthParameter, amountParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return MintTestTokensTransaction(values.Uint64("loopLength"), values.Uint64("amount")), nil
+			},
+		},
+	},
+	{
+		Label: "TransferTestTokens",
+		Template: &FuncTemplate{
+			Schema: Parameters{loopLengthParameter, recipientParameter, amountParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				recipient, err := decodeAddress("recipient", values.String("recipient"))
+				if err != nil {
+					return nil, err
+				}
+				return TransferTestTokensTransaction(values.Uint64("loopLength"), recipient, values.Uint64("amount")), nil
+			},
+		},
+	},
+	{
+		Label: "BatchTransferTestTokens",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "recipients",
+					Type:        ParameterTypeStrings,
+					Description: "Hex-encoded addresses of the recipients.",
+					Min:         bound(1),
+				},
+				amountParameter,
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				recipients, err := decodeAddresses("recipient", values.Strings("recipients"))
+				if err != nil {
+					return nil, err
+				}
+				return NewBatchTransferTestTokensTransaction(recipients, values.Uint64("amount"))
+			},
+		},
+	},
+	{
+		Label: "BurnTestTokens",
+		Template: &FuncTemplate{
+			Schema: Parameters{loopLengthParameter, amountParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return BurnTestTokensTransaction(values.Uint64("loopLength"), values.Uint64("amount")), nil
+			},
+		},
+	},
+	{
+		Label: "IncrementCounter",
+		Template: &FuncTemplate{
+			Schema: counterParameters,
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewIncrementCounterTransaction(
+					values.Uint64("loopLength"),
+					CounterScope(values.String("scope")),
+				)
+			},
+		},
+	},
+	{
+		Label: "IncrementKeyedCounter",
+		Template: &FuncTemplate{
+			Schema: keyedCounterParameters,
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewIncrementKeyedCounterTransaction(
+					values.Uint64("loopLength"),
+					values.Uint64("numCounters"),
+				)
+			},
+		},
+	},
+	{
+		Label: "SetupCapabilities",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "numControllers",
+					Type:        ParameterTypeUint64,
+					Description: "Number of capabilities issued to the stored value.",
+					Default:     uint64(10),
+					Min:         bound(1),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewSetupCapabilitiesTransaction(values.Uint64("numControllers"))
+			},
+		},
+	},
+	loopTemplate("IssueAndDeleteCapability", IssueAndDeleteCapabilityTransaction),
+	loopTemplate("UnpublishAndPublishCapability", UnpublishAndPublishCapabilityTransaction),
+	loopTemplate("RetargetCapability", RetargetCapabilityTransaction),
+	loopTemplate("TagCapabilityControllers", TagCapabilityControllersTransaction),
+	loopTemplate("ForEachCapabilityController", ForEachCapabilityControllerTransaction),
+	{
+		Label: "BorrowPublishedCapability",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				loopLengthParameter,
+				{
+					Name:        "owner",
+					Type:        ParameterTypeString,
+					Description: "Hex-encoded address of the account that published the capability.",
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				owner, err := decodeAddress("owner", values.String("owner"))
+				if err != nil {
+					return nil, err
+				}
+				return BorrowPublishedCapabilityTransaction(values.Uint64("loopLength"), owner), nil
+			},
+		},
+	},
+	{
+		Label: "PublishInboxCapabilities",
+		Template: &FuncTemplate{
+			Schema: Parameters{numCapabilitiesParameter, recipientParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				recipient, err := decodeAddress("recipient", values.String("recipient"))
+				if err != nil {
+					return nil, err
+				}
+				return NewPublishInboxCapabilitiesTransaction(values.Uint64("numCapabilities"), recipient)
+			},
+		},
+	},
+	{
+		Label: "ClaimInboxCapabilities",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				numCapabilitiesParameter,
+				{
+					Name:        "provider",
+					Type:        ParameterTypeString,
+					Description: "Hex-encoded address of the account that published the capabilities.",
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				provider, err := decodeAddress("provider", values.String("provider"))
+				if err != nil {
+					return nil, err
+				}
+				return NewClaimInboxCapabilitiesTransaction(values.Uint64("numCapabilities"), provider)
+			},
+		},
+	},
+	{
+		Label: "TeardownInboxCapabilities",
+		Template: &FuncTemplate{
+			Schema: Parameters{numCapabilitiesParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return TeardownInboxCapabilitiesTransaction(values.Uint64("numCapabilities")), nil
+			},
+		},
+	},
+	{
+		Label: "PublishAndUnpublishInboxCapability",
+		Template: &FuncTemplate{
+			Schema: Parameters{loopLengthParameter, recipientParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				recipient, err := decodeAddress("recipient", values.String("recipient"))
+				if err != nil {
+					return nil, err
+				}
+				return PublishAndUnpublishInboxCapabilityTransaction(values.Uint64("loopLength"), recipient), nil
+			},
+		},
+	},
+	{
+		Label: "LinkChildAccount",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "parent",
+					Type:        ParameterTypeString,
+					Description: "Hex-encoded address of the parent account.",
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				parent, err := decodeAddress("parent", values.String("parent"))
+				if err != nil {
+					return nil, err
+				}
+				return LinkChildAccountTransaction(parent), nil
+			},
+		},
+	},
+	{
+		Label: "ClaimChildAccount",
+		Template: &FuncTemplate{
+			Schema: Parameters{childParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				child, err := decodeAddress("child", values.String("child"))
+				if err != nil {
+					return nil, err
+				}
+				return ClaimChildAccountTransaction(child), nil
+			},
+		},
+	},
+	{
+		Label: "ChildAccountAction",
+		Template: &FuncTemplate{
+			Schema: Parameters{loopLengthParameter, childParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				child, err := decodeAddress("child", values.String("child"))
+				if err != nil {
+					return nil, err
+				}
+				return ChildAccountActionTransaction(values.Uint64("loopLength"), child), nil
+			},
+		},
+	},
+	fixedTemplate("RevokeChildAccount", RevokeChildAccountTransaction),
+	{
+		Label: "RemoveChildAccount",
+		Template: &FuncTemplate{
+			Schema: Parameters{childParameter},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				child, err := decodeAddress("child", values.String("child"))
+				if err != nil {
+					return nil, err
+				}
+				return RemoveChildAccountTransaction(child), nil
+			},
+		},
+	},
+	loopTemplate("IssueAndRevokeAccountCapability", IssueAndRevokeAccountCapabilityTransaction),
+	{
+		Label: "CreateNewAccountsWithKeys",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "publicKeys",
+					Type:        ParameterTypeStrings,
+					Description: "Hex-encoded public keys, one per new account.",
+					Min:         bound(1),
+				},
+				signatureAlgorithmParameter,
+				hashAlgorithmParameter,
+				{
+					Name:        "keysPerAccount",
+					Type:        ParameterTypeUint64,
+					Description: "Number of times the key is added to each account.",
+					Default:     uint64(1),
+					Min:         bound(1),
+				},
+				{
+					Name:        "fundingAmount",
+					Type:        ParameterTypeUint64,
+					Description: "Amount each account is funded with, in UFix64 units.",
+					Default:     uint64(0),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				publicKeys, err := decodePublicKeys(values.String("signatureAlgorithm"), values.Strings("publicKeys"))
+				if err != nil {
+					return nil, err
+				}
+				return NewCreateNewAccountsWithKeysTransaction(
+					publicKeys,
+					crypto.StringToHashAlgorithm(values.String("hashAlgorithm")),
+					values.Uint64("keysPerAccount"),
+					values.Uint64("fundingAmount"),
+				)
+			},
+		},
+	},
+	{
+		Label: "AddSigningKeyToAccount",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				loopLengthParameter,
+				{
+					Name:        "publicKey",
+					Type:        ParameterTypeString,
+					Description: "Hex-encoded public key.",
+				},
+				signatureAlgorithmParameter,
+				hashAlgorithmParameter,
+				{
+					Name:        "weight",
+					Type:        ParameterTypeUint64,
+					Description: "Weight of each added key.",
+					Default:     uint64(1000),
+					Max:         bound(1000),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				publicKeys, err := decodePublicKeys(values.String("signatureAlgorithm"), []string{values.String("publicKey")})
+				if err != nil {
+					return nil, err
+				}
+				return NewAddSigningKeyToAccountTransaction(
+					values.Uint64("loopLength"),
+					publicKeys[0],
+					crypto.StringToHashAlgorithm(values.String("hashAlgorithm")),
+					values.Uint64("weight"),
+				)
+			},
+		},
+	},
+	{
+		Label: "StoreAndLoadDictString",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "dictLen",
+					Type:        ParameterTypeUint64,
+					Description: "Number of dictionary entries.",
+					Default:     uint64(100),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewStoreAndLoadDictStringTransaction(values.Uint64("dictLen"))
+			},
+		},
+	},
+	{
+		Label: "StringToLower",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				loopLengthParameter,
+				{
+					Name:        "stringLen",
+					Type:        ParameterTypeUint64,
+					Description: "Length of the lowered string.",
+					Default:     uint64(100),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewStringToLowerTransaction(values.Uint64("loopLength"), values.Uint64("stringLen"))
+			},
+		},
+	},
+	{
+		Label: "VerifySignature",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "numKeys",
+					Type:        ParameterTypeUint64,
+					Description: "Number of keys in the key list.",
+					Default:     uint64(1),
+					Min:         bound(1),
+				},
+				{
+					Name:        "signatures",
+					Type:        ParameterTypeStrings,
+					Description: "Hex-encoded signatures, one per key.",
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewVerifySignatureTransaction(values.Uint64("numKeys"), values.Strings("signatures"))
+			},
+		},
+	},
+	{
+		Label: "AggregateBLSAggregateSignature",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "signatures",
+					Type:        ParameterTypeStrings,
+					Description: "Hex-encoded BLS signatures to aggregate.",
+					Min:         bound(1),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				signatures := values.Strings("signatures")
+				return NewAggregateBLSAggregateSignatureTransaction(len(signatures), signatures)
+			},
+		},
+	},
+	{
+		Label: "AggregateBLSAggregateKeys",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "numSigs",
+					Type:        ParameterTypeUint64,
+					Description: "Number of generated public keys to aggregate.",
+					Default:     uint64(10),
+					Min:         bound(1),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewAggregateBLSAggregateKeysTransaction(int(values.Uint64("numSigs")))
+			},
+		},
+	},
+	{
+		Label: "BLSVerifySignature",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "publicKeys",
+					Type:        ParameterTypeStrings,
+					Description: "Hex-encoded BLS public keys.",
+					Min:         bound(1),
+				},
+				{
+					Name:        "signatures",
+					Type:        ParameterTypeStrings,
+					Description: "Hex-encoded BLS signatures, one per public key.",
+					Min:         bound(1),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				publicKeys, err := decodePublicKeys(crypto.BLS_BLS12_381.String(), values.Strings("publicKeys"))
+				if err != nil {
+					return nil, err
+				}
+				return NewBLSVerifySignatureTransaction(len(publicKeys), publicKeys, values.Strings("signatures"))
+			},
+		},
+	},
+	{
+		Label: "EmitEventWithString",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "dictLen",
+					Type:        ParameterTypeUint64,
+					Description: "Number of dictionary entries of the emitted event.",
+					Default:     uint64(100),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewEmitEventWithStringTransaction(values.Uint64("dictLen"))
+			},
+		},
+	},
+	{
+		Label: "ScheduledTransactionAndExecuteWithLargeData",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				loopLengthParameter,
+				{
+					Name:        "dataSize",
+					Type:        ParameterTypeUint64,
+					Description: "Size of the scheduled data, in units of 100 bytes.",
+					Default:     uint64(10),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewScheduledTransactionAndExecuteWithLargeDataTransaction(
+					values.Uint64("loopLength"),
+					values.Uint64("dataSize"),
+				)
+			},
+		},
+	},
+	{
+		Label: "ScheduledTransactionAndExecuteWithLargeArray",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				loopLengthParameter,
+				{
+					Name:        "arraySize",
+					Type:        ParameterTypeUint64,
+					Description: "Number of elements of the scheduled array.",
+					Default:     uint64(100),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return ScheduledTransactionAndExecuteWithLargeArrayTransaction(
+					values.Uint64("loopLength"),
+					values.Uint64("arraySize"),
+				), nil
+			},
+		},
+	},
+	{
+		Label: "StorageFill",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "fillLen",
+					Type:        ParameterTypeUint64,
+					Description: "Length of the stored string, in bytes.",
+					Default:     uint64(1000),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewStorageFillTransaction(values.Uint64("fillLen"))
+			},
+		},
+	},
+	{
+		Label: "StorageBelowCapacity",
+		Template: &FuncTemplate{
+			Schema: storageCapacityParameters,
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewStorageBelowCapacityTransaction(
+					values.Uint64("storageUsed"),
+					values.Uint64("storageCapacity"),
+					values.Uint64("margin"),
+				)
+			},
+		},
+	},
+	{
+		Label: "StorageAboveCapacity",
+		Template: &FuncTemplate{
+			Schema: storageCapacityParameters,
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewStorageAboveCapacityTransaction(
+					values.Uint64("storageUsed"),
+					values.Uint64("storageCapacity"),
+					values.Uint64("margin"),
+				)
+			},
+		},
+	},
+	{
+		Label: "ComputationLimit",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "gasLimit",
+					Type:        ParameterTypeUint64,
+					Description: "Computation limit of the transaction.",
+					Default:     uint64(9999),
+				},
+				{
+					Name:        "fraction",
+					Type:        ParameterTypeFloat64,
+					Description: "Fraction of the computation limit to consume.",
+					Default:     0.5,
+					Min:         bound(0),
+				},
+				{
+					Name:        "iterationsPerUnit",
+					Type:        ParameterTypeUint64,
+					Description: "Loop iterations per unit of computation on the target network.",
+					Min:         bound(1),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewComputationLimitTransaction(
+					values.Uint64("gasLimit"),
+					values.Float64("fraction"),
+					values.Uint64("iterationsPerUnit"),
+				)
+			},
+		},
+	},
+	{
+		Label: "MemoryLimit",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "memoryLimit",
+					Type:        ParameterTypeUint64,
+					Description: "Memory limit of the transaction, in bytes.",
+				},
+				{
+					Name:        "fraction",
+					Type:        ParameterTypeFloat64,
+					Description: "Fraction of the memory limit to allocate.",
+					Default:     0.5,
+					Min:         bound(0),
+				},
+				{
+					Name:        "chunkLen",
+					Type:        ParameterTypeUint64,
+					Description: "Length of the string allocated per iteration.",
+					Default:     uint64(1024),
+					Min:         bound(1),
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewMemoryLimitTransaction(
+					values.Uint64("memoryLimit"),
+					values.Float64("fraction"),
+					values.Uint64("chunkLen"),
+				)
+			},
+		},
+	},
+	{
+		Label: "StoreGeneratedData",
+		Template: &FuncTemplate{
+			Schema:    generatedDataParameters,
+			Construct: storeGeneratedData,
+		},
+	},
+	{
+		Label: "Failing",
+		Template: &FuncTemplate{
+			Schema: Parameters{
+				{
+					Name:        "kind",
+					Type:        ParameterTypeString,
+					Description: "Way the transaction fails.",
+					Default:     string(FailurePanic),
+					Enum:        failureKindNames(),
+				},
+				{
+					Name:        "placement",
+					Type:        ParameterTypeString,
+					Description: "Block the failing code is placed in.",
+					Default:     string(PlacementPrepare),
+					Enum:        []string{string(PlacementPrepare), string(PlacementExecute)},
+				},
+			},
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return NewFailingTransaction(
+					FailureKind(values.String("kind")),
+					Placement(values.String("placement")),
+				)
+			},
+		},
+	},
+}
+
+var storageCapacityParameters = Parameters{
+	{
+		Name:        "storageUsed",
+		Type:        ParameterTypeUint64,
+		Description: "Storage used by the signer while nothing is stored at /storage/AStFill.",
+	},
+	{
+		Name:        "storageCapacity",
+		Type:        ParameterTypeUint64,
+		Description: "Storage capacity of the signer.",
+	},
+	{
+		Name:        "margin",
+		Type:        ParameterTypeUint64,
+		Description: "Distance to the capacity, in bytes.",
+		Default:     uint64(1000),
+	},
+}
+
+func failureKindNames() []string {
+	names := make([]string, 0, len(FailureKinds))
+	for _, kind := range FailureKinds {
+		names = append(names, string(kind))
+	}
+	return names
+}
+
+// RegisterBuiltinTemplates registers the BuiltinTemplates.
+func RegisterBuiltinTemplates(registry *MapRegistry) error {
+	for _, named := range BuiltinTemplates {
+		err := registry.RegisterTemplate(named.Label, named.Template)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
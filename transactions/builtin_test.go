@@ -0,0 +1,57 @@
+package transactions
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestBuiltinTemplatesParse checks that every builtin template that can be constructed
+// from its defaults renders source that parses, and whose entitlements match.
+func TestBuiltinTemplatesParse(t *testing.T) {
+	registry := NewRegistry()
+	err := RegisterBuiltinTemplates(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, named := range BuiltinTemplates {
+		required := slices.ContainsFunc(named.Template.Parameters(), func(p Parameter) bool {
+			return p.Default == nil
+		})
+		if required {
+			continue
+		}
+		t.Run(named.Label, func(t *testing.T) {
+			tx, err := named.Template.New(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			requireParses(t, tx)
+		})
+	}
+
+	mismatches, errs := CheckRegistryEntitlements(registry)
+	for label, err := range errs {
+		t.Errorf("failed to check entitlements of %s: %s", label, err)
+	}
+	for label, reports := range mismatches {
+		t.Errorf("entitlements of %s do not match: %v", label, reports)
+	}
+}
+
+func TestFixedTemplateReturnsCopy(t *testing.T) {
+	template := fixedTemplate("Fixed", RevokeChildAccountTransaction).Template
+
+	tx, err := template.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	simple := tx.(*SimpleTransaction)
+	simple.SetPrepareBlock("")
+	simple.GetAuthorizers()[0].Entitlements[0] = EntitlementStorage
+
+	if RevokeChildAccountTransaction.GetPrepareBlock() == "" ||
+		RevokeChildAccountTransaction.GetAuthorizers()[0].Entitlements[0] == EntitlementStorage {
+		t.Error("modifying the constructed transaction modified the fixed transaction")
+	}
+}
@@ -0,0 +1,183 @@
+package transactions
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// CAPABILITY TRANSACTIONS
+
+// The capability transactions operate on the capabilities set up by SetupCapabilitiesTransaction:
+// controllers of capabilities to the Int stored at capabilityTargetPath,
+// the first of which is published at capabilityPublicPath.
+const (
+	capabilityTargetPath          = "/storage/AStCapTarget"
+	capabilityAlternateTargetPath = "/storage/AStCapTarget2"
+	capabilityPublicPath          = "/public/AStCap"
+)
+
+// publishedCapabilityController borrows the controller of the published capability.
+var publishedCapabilityController = fmt.Sprintf(
+	`
+		let published = signer.capabilities.get<&Int>(%s)
+		let controller = signer.capabilities.storage.getController(byCapabilityID: published.id)
+			?? panic("signer has no published capability")
+	`,
+	capabilityPublicPath,
+)
+
+// SetupCapabilitiesTransaction stores the targets of the capability transactions,
+// issues numControllers capabilities and publishes the first one, unless the signer is already set up.
+var SetupCapabilitiesTransaction = func(numControllers uint64) *SimpleTransaction {
+	return must(NewSetupCapabilitiesTransaction(numControllers))
+}
+
+func NewSetupCapabilitiesTransaction(numControllers uint64) (*SimpleTransaction, error) {
+	err := checkNonZero("numControllers", numControllers)
+	if err != nil {
+		return nil, err
+	}
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				if signer.storage.type(at: %[1]s) == nil {
+					signer.storage.save(1, to: %[1]s)
+					signer.storage.save(2, to: %[2]s)
+					%[4]s
+					let cap = signer.capabilities.storage.getControllers(forPath: %[1]s)[0].capability as! Capability<&Int>
+					signer.capabilities.publish(cap, at: %[3]s)
+				}
+			`,
+			capabilityTargetPath,
+			capabilityAlternateTargetPath,
+			capabilityPublicPath,
+			LoopTemplate(
+				numControllers,
+				fmt.Sprintf(`signer.capabilities.storage.issue<&Int>(%s)`, capabilityTargetPath),
+			),
+		),
+	).SetAuthorizers(Signer(
+		EntitlementSaveValue,
+		EntitlementIssueStorageCapabilityController,
+		EntitlementGetStorageCapabilityController,
+		EntitlementPublishCapability,
+	)), nil
+}
+
+// IssueAndDeleteCapabilityTransaction issues a capability and deletes its controller, loopLength times.
+var IssueAndDeleteCapabilityTransaction = func(loopLength uint64) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				let cap = signer.capabilities.storage.issue<&Int>(%s)
+				signer.capabilities.storage.getController(byCapabilityID: cap.id)!.delete()
+			`,
+			capabilityTargetPath,
+		),
+	).SetAuthorizers(Signer(
+		EntitlementIssueStorageCapabilityController,
+		EntitlementGetStorageCapabilityController,
+	)).SetExpectedEvents(
+		ExpectedEvent{Type: EventStorageCapabilityControllerIssued, Count: loopLength},
+		ExpectedEvent{Type: EventStorageCapabilityControllerDeleted, Count: loopLength},
+	)
+}
+
+// UnpublishAndPublishCapabilityTransaction unpublishes the published capability
+// and publishes it again, loopLength times.
+var UnpublishAndPublishCapabilityTransaction = func(loopLength uint64) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				let cap = signer.capabilities.unpublish(%[1]s)
+					?? panic("signer has no published capability")
+				signer.capabilities.publish(cap, at: %[1]s)
+			`,
+			capabilityPublicPath,
+		),
+	).SetAuthorizers(Signer(
+		EntitlementUnpublishCapability,
+		EntitlementPublishCapability,
+	)).SetExpectedEvents(
+		ExpectedEvent{Type: EventCapabilityUnpublished, Count: loopLength},
+		ExpectedEvent{Type: EventCapabilityPublished, Count: loopLength},
+	)
+}
+
+// RetargetCapabilityTransaction retargets the controller of the published capability
+// alternately to two stored values, loopLength times.
+var RetargetCapabilityTransaction = func(loopLength uint64) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				%s
+				%s
+			`,
+			publishedCapabilityController,
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(
+					`controller.retarget(i %% 2 == 0 ? %s : %s)`,
+					capabilityTargetPath,
+					capabilityAlternateTargetPath,
+				),
+			),
+		),
+	).SetAuthorizers(Signer(EntitlementGetStorageCapabilityController)).
+		SetExpectedEvents(ExpectedEvent{Type: EventStorageCapabilityControllerTargetChanged, Count: loopLength})
+}
+
+// TagCapabilityControllersTransaction iterates the controllers of the capabilities
+// to the stored Int with getControllers and sets the tag of each, loopLength times.
+var TagCapabilityControllersTransaction = func(loopLength uint64) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				for controller in signer.capabilities.storage.getControllers(forPath: %s) {
+					controller.setTag(i.toString())
+				}
+			`,
+			capabilityTargetPath,
+		),
+	).SetAuthorizers(Signer(EntitlementGetStorageCapabilityController))
+}
+
+// ForEachCapabilityControllerTransaction iterates the controllers of the capabilities
+// to the stored Int with forEachController, loopLength times.
+var ForEachCapabilityControllerTransaction = func(loopLength uint64) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				var count = 0
+				signer.capabilities.storage.forEachController(
+					forPath: %s,
+					fun (controller: &StorageCapabilityController): Bool {
+						count = count + 1
+						return true
+					}
+				)
+			`,
+			capabilityTargetPath,
+		),
+	).SetAuthorizers(Signer(EntitlementGetStorageCapabilityController))
+}
+
+// BorrowPublishedCapabilityTransaction borrows the capability published by owner loopLength times.
+var BorrowPublishedCapabilityTransaction = func(loopLength uint64, owner flow.Address) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				let value = getAccount(%s).capabilities.borrow<&Int>(%s)
+					?? panic("owner has no published capability")
+			`,
+			owner.HexWithPrefix(),
+			capabilityPublicPath,
+		),
+	).SetAuthorizers(Signer())
+}
@@ -0,0 +1,426 @@
+package transactions
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser"
+)
+
+// CompositeTransaction combines several transactions into one.
+//
+// The prepare and execute blocks of each part are placed in their own scope,
+// so local declarations of different parts (e.g. the loop variable i) do not collide.
+// Cadence only accepts field initializations at the top level of the prepare block,
+// so prepare blocks that access self are not scoped. Instead, their top-level declarations
+// that collide with declarations of earlier unscoped blocks are renamed, e.g. i to i_2 in the second part.
+// Identical field declarations are deduplicated; parts must not initialize the same field.
+// Conditions are concatenated, authorizers with the same name are merged,
+// and the expected events of all parts are summed.
+// The imports of the parts are merged; parts must not import the same contract from different addresses.
+//
+// The setters apply to an additional, unscoped part that is placed after all other parts.
+// They return a copy of the whole composite as a SimpleTransaction, so setters chained on the result
+// modify the copy, and not the composite.
+type CompositeTransaction struct {
+	parts []Transaction
+	own   *SimpleTransaction
+	// fieldDeclarations are the merged field declarations of all parts,
+	// which are checked for conflicts when the parts or the additional field declarations are set
+	fieldDeclarations string
+	// imports are the merged imports of all parts
+	imports Imports
+}
+
+var _ Transaction = (*CompositeTransaction)(nil)
+
+// NewCompositeTransaction combines the parts, in order.
+// It returns an error if the field declarations of a part do not parse,
+// two parts declare the same field differently, or import the same contract from different addresses.
+func NewCompositeTransaction(parts ...Transaction) (*CompositeTransaction, error) {
+	fieldDeclarations, err := mergeFieldDeclarations(parts)
+	if err != nil {
+		return nil, err
+	}
+	imports, err := mergeImports(parts)
+	if err != nil {
+		return nil, err
+	}
+	return &CompositeTransaction{
+		parts:             parts,
+		own:               NewSimpleTransaction(""),
+		fieldDeclarations: fieldDeclarations,
+		imports:           imports,
+	}, nil
+}
+
+func (c *CompositeTransaction) all() []Transaction {
+	return append(slices.Clone(c.parts), c.own)
+}
+
+func (c *CompositeTransaction) SetPrepareBlock(prepareBlock string) *SimpleTransaction {
+	c.own.SetPrepareBlock(prepareBlock)
+	return c.Simple()
+}
+
+func (c *CompositeTransaction) SetExecuteBlock(executeBlock string) *SimpleTransaction {
+	c.own.SetExecuteBlock(executeBlock)
+	return c.Simple()
+}
+
+// SetFieldDeclarations panics if the declarations do not parse,
+// or conflict with the field declarations of the parts.
+func (c *CompositeTransaction) SetFieldDeclarations(fieldDeclarations string) *SimpleTransaction {
+	own := c.own.Copy().SetFieldDeclarations(fieldDeclarations)
+	merged, err := mergeFieldDeclarations(append(slices.Clone(c.parts), own))
+	if err != nil {
+		panic(err)
+	}
+	c.own = own
+	c.fieldDeclarations = merged
+	return c.Simple()
+}
+
+func (c *CompositeTransaction) SetPreConditions(preConditions string) *SimpleTransaction {
+	c.own.SetPreConditions(preConditions)
+	return c.Simple()
+}
+
+func (c *CompositeTransaction) SetPostConditions(postConditions string) *SimpleTransaction {
+	c.own.SetPostConditions(postConditions)
+	return c.Simple()
+}
+
+func (c *CompositeTransaction) SetAuthorizers(authorizers ...Authorizer) *SimpleTransaction {
+	c.own.SetAuthorizers(authorizers...)
+	return c.Simple()
+}
+
+func (c *CompositeTransaction) SetExpectedEvents(expectedEvents ...ExpectedEvent) *SimpleTransaction {
+	c.own.SetExpectedEvents(expectedEvents...)
+	return c.Simple()
+}
+
+func (c *CompositeTransaction) SetExpectedFailure(expectedFailure *ExpectedFailure) *SimpleTransaction {
+	c.own.SetExpectedFailure(expectedFailure)
+	return c.Simple()
+}
+
+// SetImports panics if the imports conflict with the imports of the parts.
+func (c *CompositeTransaction) SetImports(imports Imports) *SimpleTransaction {
+	own := c.own.Copy().SetImports(imports)
+	merged, err := mergeImports(append(slices.Clone(c.parts), own))
+	if err != nil {
+		panic(err)
+	}
+	c.own = own
+	c.imports = merged
+	return c.Simple()
+}
+
+// Simple returns a SimpleTransaction with the blocks, authorizers, expectations and imports of the composite.
+func (c *CompositeTransaction) Simple() *SimpleTransaction {
+	tx := NewSimpleTransaction(c.GetPrepareBlock()).
+		SetExecuteBlock(c.GetExecuteBlock()).
+		SetFieldDeclarations(c.GetFieldDeclarations()).
+		SetPreConditions(c.GetPreConditions()).
+		SetPostConditions(c.GetPostConditions()).
+		SetAuthorizers(c.GetAuthorizers()...).
+		SetExpectedEvents(c.GetExpectedEvents()...).
+		SetImports(maps.Clone(c.imports))
+	if expectedFailure := c.GetExpectedFailure(); expectedFailure != nil {
+		copied := *expectedFailure
+		tx.SetExpectedFailure(&copied)
+	}
+	return tx
+}
+
+var selfPattern = regexp.MustCompile(`\bself\.`)
+
+func (c *CompositeTransaction) GetPrepareBlock() string {
+	return c.scopedBlocks(Transaction.GetPrepareBlock, selfPattern.MatchString)
+}
+
+func (c *CompositeTransaction) GetExecuteBlock() string {
+	return c.scopedBlocks(Transaction.GetExecuteBlock, nil)
+}
+
+func (c *CompositeTransaction) GetFieldDeclarations() string {
+	return c.fieldDeclarations
+}
+
+func (c *CompositeTransaction) GetPreConditions() string {
+	return c.concatenatedBlocks(Transaction.GetPreConditions)
+}
+
+func (c *CompositeTransaction) GetPostConditions() string {
+	return c.concatenatedBlocks(Transaction.GetPostConditions)
+}
+
+// GetAuthorizers merges the authorizers of all parts by name, in order of first appearance.
+// Parts without declared authorizers contribute the DefaultAuthorizers.
+func (c *CompositeTransaction) GetAuthorizers() []Authorizer {
+	var merged []Authorizer
+	indices := map[string]int{}
+	for _, part := range c.all() {
+		authorizers := part.GetAuthorizers()
+		if authorizers == nil {
+			if part == Transaction(c.own) {
+				continue
+			}
+			authorizers = DefaultAuthorizers
+		}
+
+		for _, authorizer := range authorizers {
+			index, ok := indices[authorizer.Name]
+			if !ok {
+				indices[authorizer.Name] = len(merged)
+				merged = append(merged, Authorizer{
+					Name:         authorizer.Name,
+					Entitlements: slices.Clone(authorizer.Entitlements),
+				})
+				continue
+			}
+			for _, entitlement := range authorizer.Entitlements {
+				if !slices.Contains(merged[index].Entitlements, entitlement) {
+					merged[index].Entitlements = append(merged[index].Entitlements, entitlement)
+				}
+			}
+		}
+	}
+	if merged == nil {
+		return []Authorizer{}
+	}
+	return merged
+}
+
+func (c *CompositeTransaction) GetExpectedEvents() []ExpectedEvent {
+	lists := make([][]ExpectedEvent, 0, len(c.parts)+1)
+	for _, part := range c.all() {
+		lists = append(lists, part.GetExpectedEvents())
+	}
+	return mergeExpectedEvents(lists...)
+}
+
+// GetExpectedFailure returns the expected failure of the additional part, if set,
+// and otherwise the first expected failure of the parts.
+func (c *CompositeTransaction) GetExpectedFailure() *ExpectedFailure {
+	if expectedFailure := c.own.GetExpectedFailure(); expectedFailure != nil {
+		return expectedFailure
+	}
+	for _, part := range c.parts {
+		if expectedFailure := part.GetExpectedFailure(); expectedFailure != nil {
+			return expectedFailure
+		}
+	}
+	return nil
+}
+
+func (c *CompositeTransaction) GetImports() Imports {
+	return c.imports
+}
+
+// scopedBlocks places the block of each part in its own scope, unless unscoped reports true for it,
+// followed by the block of the additional part.
+func (c *CompositeTransaction) scopedBlocks(
+	get func(Transaction) string,
+	unscoped func(string) bool,
+) string {
+	builder := strings.Builder{}
+	// names declared at the top level of the unscoped blocks so far
+	declared := map[string]bool{}
+	for index, part := range c.parts {
+		block := get(part)
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		if unscoped != nil && unscoped(block) {
+			block = renameDeclarations(block, declared, fmt.Sprintf("_%d", index+1))
+			builder.WriteString(TrimAndReplaceIndentation(block, 0))
+			continue
+		}
+		builder.WriteString("if true {\n")
+		builder.WriteString(TrimAndReplaceIndentation(block, 4))
+		builder.WriteString("}\n")
+	}
+
+	own := get(c.own)
+	if strings.TrimSpace(own) != "" {
+		own = renameDeclarations(own, declared, "_own")
+		builder.WriteString(TrimAndReplaceIndentation(own, 0))
+	}
+	return builder.String()
+}
+
+// renameDeclarations renames the variables and functions declared at the top level of block
+// whose names are already declared, and all identifiers referring to them, by appending suffix.
+// It adds the names declared by block to declared.
+// Blocks that do not parse are returned unchanged.
+func renameDeclarations(block string, declared map[string]bool, suffix string) string {
+	statements, errs := parser.ParseStatements(nil, []byte(block), parser.Config{})
+	if len(errs) > 0 {
+		return block
+	}
+
+	renamed := map[string]bool{}
+	var offsets []int
+	for _, statement := range statements {
+		var identifier ast.Identifier
+		switch declaration := statement.(type) {
+		case *ast.VariableDeclaration:
+			identifier = declaration.Identifier
+		case *ast.FunctionDeclaration:
+			identifier = declaration.Identifier
+		default:
+			continue
+		}
+
+		name := identifier.Identifier
+		if declared[name] {
+			renamed[name] = true
+			offsets = append(offsets, identifier.Pos.Offset)
+		}
+		declared[name] = true
+	}
+	if len(renamed) == 0 {
+		return block
+	}
+
+	for _, statement := range statements {
+		ast.Inspect(statement, func(element ast.Element) bool {
+			if expression, ok := element.(*ast.IdentifierExpression); ok && renamed[expression.Identifier.Identifier] {
+				offsets = append(offsets, expression.Identifier.Pos.Offset)
+			}
+			return true
+		})
+	}
+
+	// insert the suffixes from the end, so earlier offsets stay valid
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+	result := block
+	for _, offset := range offsets {
+		end := offset
+		for end < len(result) && isIdentifierByte(result[end]) {
+			end++
+		}
+		result = result[:end] + suffix + result[end:]
+	}
+	return result
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' ||
+		'a' <= b && b <= 'z' ||
+		'A' <= b && b <= 'Z' ||
+		'0' <= b && b <= '9'
+}
+
+func (c *CompositeTransaction) concatenatedBlocks(get func(Transaction) string) string {
+	builder := strings.Builder{}
+	for _, part := range c.all() {
+		block := get(part)
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		builder.WriteString(TrimAndReplaceIndentation(block, 0))
+	}
+	return builder.String()
+}
+
+// mergeImports returns the imports of the parts.
+// It returns an error if two parts import the same contract from different addresses.
+func mergeImports(parts []Transaction) (Imports, error) {
+	var merged Imports
+	for _, part := range parts {
+		for name, address := range part.GetImports() {
+			existing, ok := merged[name]
+			if ok && existing != address {
+				return nil, fmt.Errorf(
+					"conflicting imports of contract %s: %s and %s",
+					name,
+					existing.HexWithPrefix(),
+					address.HexWithPrefix(),
+				)
+			}
+			if merged == nil {
+				merged = Imports{}
+			}
+			merged[name] = address
+		}
+	}
+	return merged, nil
+}
+
+// mergeFieldDeclarations returns the field declarations of the parts, without duplicates.
+// Declarations are compared after parsing, ignoring whitespace.
+// It returns an error if two parts declare the same field differently.
+func mergeFieldDeclarations(parts []Transaction) (string, error) {
+	var declarations []string
+	byName := map[string]string{}
+	for _, part := range parts {
+		fields, err := parseFieldDeclarations(part.GetFieldDeclarations())
+		if err != nil {
+			return "", err
+		}
+		for _, field := range fields {
+			existing, ok := byName[field.name]
+			if !ok {
+				byName[field.name] = field.declaration
+				declarations = append(declarations, field.declaration)
+				continue
+			}
+			if existing != field.declaration {
+				return "", fmt.Errorf(
+					"conflicting declarations of field %s: %q and %q",
+					field.name,
+					existing,
+					field.declaration,
+				)
+			}
+		}
+	}
+
+	if len(declarations) == 0 {
+		return "", nil
+	}
+	return strings.Join(declarations, "\n") + "\n", nil
+}
+
+type fieldDeclaration struct {
+	name string
+	// declaration is the source of the declaration, with whitespace normalized to single spaces
+	declaration string
+}
+
+// parseFieldDeclarations parses the field declarations of a transaction.
+func parseFieldDeclarations(fieldDeclarations string) ([]fieldDeclaration, error) {
+	if strings.TrimSpace(fieldDeclarations) == "" {
+		return nil, nil
+	}
+
+	const prefix = "transaction {\n"
+	code := prefix + fieldDeclarations + "\n}"
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse field declarations: %w", err)
+	}
+	transactionDeclarations := program.TransactionDeclarations()
+	if len(transactionDeclarations) != 1 {
+		return nil, fmt.Errorf("failed to parse field declarations: %q", fieldDeclarations)
+	}
+
+	fields := make([]fieldDeclaration, 0, len(transactionDeclarations[0].Fields))
+	for _, field := range transactionDeclarations[0].Fields {
+		source := code[field.StartPos.Offset : field.EndPos.Offset+1]
+		fields = append(fields, fieldDeclaration{
+			name:        field.Identifier.Identifier,
+			declaration: strings.Join(strings.Fields(source), " "),
+		})
+	}
+	return fields, nil
+}
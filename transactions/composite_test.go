@@ -0,0 +1,101 @@
+package transactions
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCompositeTransactionRenamesDeclarations combines parts that initialize fields,
+// so their prepare blocks are not scoped, and checks that their colliding declarations are renamed
+// so the composite executes.
+func TestCompositeTransactionRenamesDeclarations(t *testing.T) {
+	first := NewSimpleTransaction(`
+		self.a = 1
+		var i = 0
+		fun double(_ x: Int): Int { return 2 * x }
+		i = double(i)
+	`).SetFieldDeclarations(`let a: Int`)
+	second := NewSimpleTransaction(`
+		self.b = 2
+		var i = 1
+		fun double(_ x: Int): Int { return x + x }
+		assert(double(i) == 2)
+	`).SetFieldDeclarations(`let b: Int`)
+	scoped := simpleTransactionWithLoop(3, `assert(i <= 3)`)
+
+	composite, err := NewCompositeTransaction(first, second, scoped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	composite.SetPrepareBlock(`
+		var i = 2
+		assert(i == 2)
+	`)
+
+	prepare := composite.GetPrepareBlock()
+	for _, renamed := range []string{"var i_2 = 1", "double_2(i_2)", "var i_own = 2"} {
+		if !strings.Contains(prepare, renamed) {
+			t.Errorf("prepare block does not contain %q:\n%s", renamed, prepare)
+		}
+	}
+
+	err = executeTransaction(composite)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompositeTransactionFieldDeclarations(t *testing.T) {
+	first := NewSimpleTransaction(`self.a = 1`).SetFieldDeclarations(`
+		let a: Int
+		let dict: {String:
+			Int}
+	`)
+	second := NewSimpleTransaction(``).SetFieldDeclarations(`
+		let a:   Int
+		let dict: {String: Int}
+	`)
+
+	composite, err := NewCompositeTransaction(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "let a: Int\nlet dict: {String: Int}\n"
+	if composite.GetFieldDeclarations() != expected {
+		t.Errorf("unexpected field declarations %q", composite.GetFieldDeclarations())
+	}
+
+	conflicting := NewSimpleTransaction(``).SetFieldDeclarations(`let a: String`)
+	_, err = NewCompositeTransaction(first, conflicting)
+	if err == nil {
+		t.Error("expected error for conflicting field declarations")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for conflicting additional field declarations")
+		}
+		if composite.GetFieldDeclarations() != expected {
+			t.Errorf("conflicting declarations modified the composite: %q", composite.GetFieldDeclarations())
+		}
+	}()
+	composite.SetFieldDeclarations(`var a: Int`)
+}
+
+func TestCompositeTransactionSettersReturnCopy(t *testing.T) {
+	composite, err := NewCompositeTransaction(EmptyLoopTransaction(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := composite.SetExecuteBlock(`log("own")`)
+	if !strings.Contains(tx.GetPrepareBlock(), "while") ||
+		!strings.Contains(tx.GetExecuteBlock(), `log("own")`) {
+		t.Errorf("setter did not return the whole composite: %s", Render(tx, nil))
+	}
+
+	tx.SetExecuteBlock("")
+	if !strings.Contains(composite.GetExecuteBlock(), `log("own")`) {
+		t.Error("modifying the returned transaction modified the composite")
+	}
+}
@@ -0,0 +1,50 @@
+package transactions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CONDITION TRANSACTIONS
+
+func conditionsOfLen(length uint64) string {
+	builder := strings.Builder{}
+	for i := uint64(0); i < length; i++ {
+		builder.WriteString("self.count > 0\n")
+	}
+	return builder.String()
+}
+
+func conditionTransaction() *SimpleTransaction {
+	return NewSimpleTransaction(
+		`self.count = 1`,
+	).SetFieldDeclarations(
+		`let count: Int`,
+	).SetAuthorizers(Signer())
+}
+
+// PreConditionTransaction evaluates loopLength trivial pre-conditions.
+var PreConditionTransaction = func(loopLength uint64) *SimpleTransaction {
+	return conditionTransaction().
+		SetPreConditions(conditionsOfLen(loopLength))
+}
+
+// PostConditionTransaction evaluates loopLength trivial post-conditions.
+var PostConditionTransaction = func(loopLength uint64) *SimpleTransaction {
+	return conditionTransaction().
+		SetPostConditions(conditionsOfLen(loopLength))
+}
+
+// PreConditionLoopTransaction runs a loop of loopLength iterations in a single pre-condition.
+var PreConditionLoopTransaction = func(loopLength uint64) *SimpleTransaction {
+	return NewSimpleTransaction("").
+		SetAuthorizers(Signer()).
+		SetPreConditions(fmt.Sprintf(`TestContract.loop(%d)`, loopLength))
+}
+
+// PostConditionLoopTransaction runs a loop of loopLength iterations in a single post-condition.
+var PostConditionLoopTransaction = func(loopLength uint64) *SimpleTransaction {
+	return NewSimpleTransaction("").
+		SetAuthorizers(Signer()).
+		SetPostConditions(fmt.Sprintf(`TestContract.loop(%d)`, loopLength))
+}
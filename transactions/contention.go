@@ -0,0 +1,116 @@
+package transactions
+
+import (
+	"fmt"
+)
+
+// CONTENTION TRANSACTIONS
+
+// CounterScope is where the counter incremented by IncrementCounterTransaction is stored,
+// which determines the transactions it conflicts with.
+type CounterScope string
+
+const (
+	// CounterScopeShared is a counter in the storage of TestContract's account.
+	// All transactions incrementing it write the same registers and conflict with each other.
+	CounterScopeShared CounterScope = "shared"
+	// CounterScopeSigner is a counter in the signer's storage.
+	// Transactions of different signers do not conflict.
+	CounterScopeSigner CounterScope = "signer"
+)
+
+// CounterScopes are all counter scopes.
+var CounterScopes = []CounterScope{
+	CounterScopeShared,
+	CounterScopeSigner,
+}
+
+// ContentionScope returns the counter scope of the account at accountIndex
+// when the first sharedAccounts accounts write the shared counter and all other accounts their own,
+// so the share of conflicting transactions is tuned by sharedAccounts.
+func ContentionScope(accountIndex int, sharedAccounts int) CounterScope {
+	if accountIndex < sharedAccounts {
+		return CounterScopeShared
+	}
+	return CounterScopeSigner
+}
+
+// IncrementCounterTransaction increments the counter of the scope loopLength times.
+var IncrementCounterTransaction = func(loopLength uint64, scope CounterScope) *SimpleTransaction {
+	return must(NewIncrementCounterTransaction(loopLength, scope))
+}
+
+func NewIncrementCounterTransaction(loopLength uint64, scope CounterScope) (*SimpleTransaction, error) {
+	switch scope {
+	case CounterScopeShared:
+		return simpleTransactionWithLoop(
+			loopLength,
+			`TestContract.increment()`,
+		).SetAuthorizers(Signer()), nil
+
+	case CounterScopeSigner:
+		return simpleTransactionWithLoop(
+			loopLength,
+			`
+				let counter = signer.storage.load<UInt64>(from: /storage/AStCounter) ?? 0
+				signer.storage.save(counter + 1, to: /storage/AStCounter)
+			`,
+		).SetAuthorizers(Signer(EntitlementLoadValue, EntitlementSaveValue)), nil
+
+	default:
+		return nil, invalidParameter("unknown counter scope: %s", scope)
+	}
+}
+
+var counterParameters = Parameters{
+	loopLengthParameter,
+	{
+		Name:        "scope",
+		Type:        ParameterTypeString,
+		Description: "Where the counter is stored. Transactions incrementing the shared counter conflict, see ContentionScope.",
+		Default:     string(CounterScopeShared),
+		Enum: []string{
+			string(CounterScopeShared),
+			string(CounterScopeSigner),
+		},
+	},
+}
+
+// counterKey is the key of the signer's counter out of numCounters keys, derived from the signer's address,
+// so all transactions of a signer increment the same key.
+const counterKey = `UInt64.fromBigEndianBytes(signer.address.toBytes())! %% %d`
+
+// IncrementKeyedCounterTransaction increments one of numCounters counters in the storage of TestContract's account
+// loopLength times. Each signer increments the counter of the key derived from its address,
+// so the transactions of signers with the same key conflict, and the contention is tuned by numCounters.
+var IncrementKeyedCounterTransaction = func(loopLength uint64, numCounters uint64) *SimpleTransaction {
+	return must(NewIncrementKeyedCounterTransaction(loopLength, numCounters))
+}
+
+func NewIncrementKeyedCounterTransaction(loopLength uint64, numCounters uint64) (*SimpleTransaction, error) {
+	err := checkNonZero("numCounters", numCounters)
+	if err != nil {
+		return nil, err
+	}
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				let key = %s
+				TestContract.incrementKey(key)
+			`,
+			fmt.Sprintf(counterKey, numCounters),
+		),
+	).SetAuthorizers(Signer()), nil
+}
+
+var keyedCounterParameters = Parameters{
+	loopLengthParameter,
+	{
+		Name:        "numCounters",
+		Type:        ParameterTypeUint64,
+		Description: "Number of hot keys. Transactions of signers with the same key conflict.",
+		Default:     uint64(10),
+		Min:         bound(1),
+	},
+}
@@ -0,0 +1,35 @@
+package transactions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence/parser"
+)
+
+func TestIncrementKeyedCounterTransaction(t *testing.T) {
+	_, err := NewIncrementKeyedCounterTransaction(1, 0)
+	if err == nil {
+		t.Error("expected error for zero counters")
+	}
+
+	tx, err := NewIncrementKeyedCounterTransaction(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireParses(t, tx)
+
+	_, err = parser.ParseProgram(nil, contract, parser.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the test runtime signs with the address 0x2a00000000000000, i.e. 42 * 2^56, which is 2 modulo 5
+	err = executeTransaction(NewSimpleTransaction(fmt.Sprintf(`
+		let key = %s
+		assert(key == 2, message: key.toString())
+	`, fmt.Sprintf(counterKey, 5))).SetAuthorizers(Signer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
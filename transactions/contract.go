@@ -16,7 +16,7 @@ var CallEmptyContractFunctionTransaction = func(
 	return simpleTransactionWithLoop(
 		loopLength,
 		`TestContract.empty()`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var EmitEventTransaction = func(
@@ -25,25 +25,88 @@ var EmitEventTransaction = func(
 	return simpleTransactionWithLoop(
 		loopLength,
 		`TestContract.emitEvent()`,
-	)
+	).SetAuthorizers(Signer()).
+		SetExpectedEvents(ExpectedEvent{Type: EventSomeEvent, Count: loopLength})
 }
 
+// MintNFTTransaction appends loopLength NFTs to the NFTs of TestContract, which grow with every run.
+// Use MintAndBurnNFTTransaction or MintNFTToSignerStorageTransaction for a stable workload,
+// or reset the contract with ResetTestContractTransaction.
 var MintNFTTransaction = func(
 	loopLength uint64,
 ) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`TestContract.mintNFT()`,
-	)
+	).SetAuthorizers(Signer())
 }
 
+// BurnMintedNFTTransaction destroys the loopLength most recently minted NFTs of TestContract.
+var BurnMintedNFTTransaction = func(
+	loopLength uint64,
+) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		`TestContract.burnNFT()`,
+	).SetAuthorizers(Signer())
+}
+
+// MintAndBurnNFTTransaction mints an NFT and burns it again loopLength times,
+// so the NFTs of TestContract do not grow.
+var MintAndBurnNFTTransaction = func(
+	loopLength uint64,
+) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		`
+			TestContract.mintNFT()
+			TestContract.burnNFT()
+		`,
+	).SetAuthorizers(Signer())
+}
+
+// MintNFTToSignerStorageTransaction creates loopLength NFTs, each replacing the previous one
+// in the signer's storage, so neither the contract nor the signer's storage grow.
+var MintNFTToSignerStorageTransaction = func(
+	loopLength uint64,
+) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		`
+			destroy signer.storage.load<@TestContract.NFT>(from: /storage/AStNFT)
+			signer.storage.save(<- TestContract.createNFT(id: UInt64(i)), to: /storage/AStNFT)
+		`,
+	).SetAuthorizers(Signer(EntitlementLoadValue, EntitlementSaveValue))
+}
+
+// ResetTestContractTransaction destroys all NFTs minted by MintNFTTransaction
+// and resets the total supply of TestContract.
+var ResetTestContractTransaction = NewSimpleTransaction(
+	`TestContract.reset()`,
+).SetAuthorizers(Signer())
+
 var EmitEventWithStringTransaction = func(
 	dictLen uint64,
 ) *SimpleTransaction {
+	return emitEventWithStringTransaction(dictLen)
+}
+
+func NewEmitEventWithStringTransaction(
+	dictLen uint64,
+) (*SimpleTransaction, error) {
+	err := checkLength("dictLen", dictLen, stringDictEntrySize(50))
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(emitEventWithStringTransaction(dictLen))
+}
+
+func emitEventWithStringTransaction(dictLen uint64) *SimpleTransaction {
 	return NewSimpleTransaction(
 		fmt.Sprintf(`
 			let dict: {String: String} = %s
 			TestContract.emitDictEvent(dict)
 		`, stringDictOfLen(dictLen, 50)),
-	)
+	).SetAuthorizers(Signer()).
+		SetExpectedEvents(ExpectedEvent{Type: EventSomeEvent2, Count: 1})
 }
@@ -0,0 +1,169 @@
+package transactions
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/parser"
+
+	"github.com/onflow/flow-standard-transactions/datagen"
+)
+
+// DATA TRANSACTIONS
+
+// StoreDataTransaction replaces the value stored at /storage/AStData with value.
+// Generate values of controlled shape and size with datagen.Generate.
+var StoreDataTransaction = func(value cadence.Value) *SimpleTransaction {
+	return must(NewStoreDataTransaction(value))
+}
+
+func NewStoreDataTransaction(value cadence.Value) (*SimpleTransaction, error) {
+	literal, err := CadenceLiteral(value)
+	if err != nil {
+		return nil, invalidParameter("failed to encode value: %s", err)
+	}
+
+	return checkSize(NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				signer.storage.load<AnyStruct>(from: /storage/AStData)
+				signer.storage.save(%s, to: /storage/AStData)
+			`,
+			literal,
+		),
+	).SetAuthorizers(Signer(EntitlementLoadValue, EntitlementSaveValue)))
+}
+
+// maxGeneratedDataDepth is the nesting limit of the Cadence parser.
+// The literals of all shapes nest at least one level per container, so no deeper data parses.
+// Shapes whose literals nest more, e.g. structs, are limited further by checkGeneratedDataNesting.
+const maxGeneratedDataDepth = 16
+
+var generatedDataParameters = Parameters{
+	{
+		Name:        "depth",
+		Type:        ParameterTypeUint64,
+		Description: "Number of container levels above the leaves.",
+		Default:     uint64(2),
+		Max:         bound(maxGeneratedDataDepth),
+	},
+	{
+		Name:        "fanOut",
+		Type:        ParameterTypeUint64,
+		Description: "Number of children of each container.",
+		Default:     uint64(10),
+		Min:         bound(1),
+	},
+	{
+		Name:        "shape",
+		Type:        ParameterTypeString,
+		Description: "Container kind of all levels.",
+		Default:     string(datagen.ShapeDictionary),
+		Enum: []string{
+			string(datagen.ShapeArray),
+			string(datagen.ShapeDictionary),
+			string(datagen.ShapeStruct),
+		},
+	},
+	{
+		Name:        "keys",
+		Type:        ParameterTypeString,
+		Description: "Distribution of dictionary keys.",
+		Default:     string(datagen.KeysSequential),
+		Enum: []string{
+			string(datagen.KeysSequential),
+			string(datagen.KeysRandom),
+			string(datagen.KeysCommonPrefix),
+		},
+	},
+	{
+		Name:        "keyLength",
+		Type:        ParameterTypeUint64,
+		Description: "Length of dictionary keys in bytes.",
+		Default:     uint64(8),
+		Min:         bound(1),
+		Max:         bound(MaxTransactionByteSize),
+	},
+	{
+		Name:        "leafSize",
+		Type:        ParameterTypeUint64,
+		Description: "Length of the leaf strings in bytes. Ignored if totalSize is set.",
+		Default:     uint64(10),
+		Max:         bound(MaxTransactionByteSize),
+	},
+	{
+		Name:        "totalSize",
+		Type:        ParameterTypeUint64,
+		Description: "Approximate total size of leaves and keys in bytes, or 0.",
+		Default:     uint64(0),
+		Max:         bound(MaxTransactionByteSize),
+	},
+	{
+		Name:        "seed",
+		Type:        ParameterTypeUint64,
+		Description: "Seed of the generated data.",
+		Default:     uint64(0),
+	},
+}
+
+func storeGeneratedData(values Values) (*SimpleTransaction, error) {
+	config := datagen.Config{
+		Depth:     int(values.Uint64("depth")),
+		FanOut:    int(values.Uint64("fanOut")),
+		Shapes:    []datagen.Shape{datagen.Shape(values.String("shape"))},
+		Keys:      datagen.KeyDistribution(values.String("keys")),
+		KeyLength: int(values.Uint64("keyLength")),
+		LeafSize:  int(values.Uint64("leafSize")),
+		TotalSize: int(values.Uint64("totalSize")),
+		Seed:      values.Uint64("seed"),
+	}
+	if config.Leaves() > MaxTransactionByteSize {
+		return nil, invalidParameter(
+			"%d leaves exceed the maximum transaction size of %d bytes",
+			config.Leaves(),
+			MaxTransactionByteSize,
+		)
+	}
+	// reject oversized data before generating it, as generating it can take a lot of memory
+	if config.Size() > MaxTransactionByteSize {
+		return nil, invalidParameter(
+			"leaves and keys of %d bytes exceed the maximum transaction size of %d bytes",
+			config.Size(),
+			MaxTransactionByteSize,
+		)
+	}
+
+	err := checkGeneratedDataNesting(config)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := datagen.Generate(config)
+	if err != nil {
+		return nil, invalidParameter("%s", err)
+	}
+	return NewStoreDataTransaction(value)
+}
+
+// checkGeneratedDataNesting rejects data whose literal nests deeper than the parser allows.
+// It generates a tree of the same depth and shapes with a single child per container and empty leaves,
+// which nests as deep as the full tree, and parses the transaction storing it.
+func checkGeneratedDataNesting(config datagen.Config) error {
+	config.FanOut = 1
+	config.LeafSize = 0
+	config.TotalSize = 0
+
+	value, err := datagen.Generate(config)
+	if err != nil {
+		return invalidParameter("%s", err)
+	}
+	tx, err := NewStoreDataTransaction(value)
+	if err != nil {
+		return err
+	}
+	_, err = parser.ParseProgram(nil, []byte(Render(tx, nil)), parser.Config{})
+	if err != nil {
+		return invalidParameter("data of depth %d nests too deep to parse: %s", config.Depth, err)
+	}
+	return nil
+}
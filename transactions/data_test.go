@@ -0,0 +1,36 @@
+package transactions
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-standard-transactions/datagen"
+)
+
+// TestStoreGeneratedDataParses checks that generated data of every accepted depth parses,
+// and that data nesting too deep to parse is rejected.
+func TestStoreGeneratedDataParses(t *testing.T) {
+	template := &FuncTemplate{
+		Schema:    generatedDataParameters,
+		Construct: storeGeneratedData,
+	}
+
+	for _, shape := range []datagen.Shape{datagen.ShapeArray, datagen.ShapeDictionary, datagen.ShapeStruct} {
+		t.Run(string(shape), func(t *testing.T) {
+			depth := uint64(0)
+			for ; depth <= maxGeneratedDataDepth+1; depth++ {
+				tx, err := template.New(map[string]any{
+					"depth":  depth,
+					"fanOut": uint64(2),
+					"shape":  string(shape),
+				})
+				if err != nil {
+					break
+				}
+				requireParses(t, tx)
+			}
+			if depth == 0 || depth > maxGeneratedDataDepth {
+				t.Errorf("accepted depths up to %d", int(depth)-1)
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser"
+)
+
+// memberEntitlements maps member paths on an authorizer, e.g. storage.save for signer.storage.save,
+// to the entitlement they require.
+var memberEntitlements = map[string]Entitlement{
+	"storage.save":   EntitlementSaveValue,
+	"storage.load":   EntitlementLoadValue,
+	"storage.copy":   EntitlementCopyValue,
+	"storage.borrow": EntitlementBorrowValue,
+
+	"contracts.add":       EntitlementAddContract,
+	"contracts.update":    EntitlementUpdateContract,
+	"contracts.tryUpdate": EntitlementUpdateContract,
+	"contracts.remove":    EntitlementRemoveContract,
+
+	"keys.add":    EntitlementAddKey,
+	"keys.revoke": EntitlementRevokeKey,
+
+	"inbox.publish":   EntitlementPublishInboxCapability,
+	"inbox.unpublish": EntitlementUnpublishInboxCapability,
+	"inbox.claim":     EntitlementClaimInboxCapability,
+
+	"capabilities.publish":   EntitlementPublishCapability,
+	"capabilities.unpublish": EntitlementUnpublishCapability,
+
+	"capabilities.storage.issue":             EntitlementIssueStorageCapabilityController,
+	"capabilities.storage.issueWithType":     EntitlementIssueStorageCapabilityController,
+	"capabilities.storage.getController":     EntitlementGetStorageCapabilityController,
+	"capabilities.storage.getControllers":    EntitlementGetStorageCapabilityController,
+	"capabilities.storage.forEachController": EntitlementGetStorageCapabilityController,
+
+	"capabilities.account.issue":             EntitlementIssueAccountCapabilityController,
+	"capabilities.account.issueWithType":     EntitlementIssueAccountCapabilityController,
+	"capabilities.account.getController":     EntitlementGetAccountCapabilityController,
+	"capabilities.account.getControllers":    EntitlementGetAccountCapabilityController,
+	"capabilities.account.forEachController": EntitlementGetAccountCapabilityController,
+}
+
+// unrestrictedMembers are member paths on an authorizer that require no entitlement.
+// Member paths that are neither unrestricted nor in memberEntitlements, e.g. signer.storage on its own,
+// are treated as escapes, as the analysis does not follow the resulting value.
+var unrestrictedMembers = map[string]bool{
+	"address":          true,
+	"balance":          true,
+	"availableBalance": true,
+
+	"storage.used":          true,
+	"storage.capacity":      true,
+	"storage.type":          true,
+	"storage.check":         true,
+	"storage.publicPaths":   true,
+	"storage.storagePaths":  true,
+	"storage.forEachPublic": true,
+	"storage.forEachStored": true,
+
+	"contracts.names":  true,
+	"contracts.get":    true,
+	"contracts.borrow": true,
+
+	"keys.get":     true,
+	"keys.forEach": true,
+	"keys.count":   true,
+
+	"capabilities.get":    true,
+	"capabilities.borrow": true,
+	"capabilities.exists": true,
+}
+
+// payerEntitlement is required on the payer of an account created with Account(payer: ...).
+const payerEntitlement = EntitlementBorrowValue
+
+// entitlementGroups maps coarse entitlements to the fine-grained entitlements they grant.
+var entitlementGroups = map[Entitlement][]Entitlement{
+	EntitlementStorage: {
+		EntitlementSaveValue,
+		EntitlementLoadValue,
+		EntitlementCopyValue,
+		EntitlementBorrowValue,
+	},
+	EntitlementContracts: {
+		EntitlementAddContract,
+		EntitlementUpdateContract,
+		EntitlementRemoveContract,
+	},
+	EntitlementKeys: {
+		EntitlementAddKey,
+		EntitlementRevokeKey,
+	},
+	EntitlementInbox: {
+		EntitlementPublishInboxCapability,
+		EntitlementUnpublishInboxCapability,
+		EntitlementClaimInboxCapability,
+	},
+	EntitlementCapabilities: {
+		EntitlementStorageCapabilities,
+		EntitlementAccountCapabilities,
+		EntitlementPublishCapability,
+		EntitlementUnpublishCapability,
+		EntitlementGetStorageCapabilityController,
+		EntitlementIssueStorageCapabilityController,
+		EntitlementGetAccountCapabilityController,
+		EntitlementIssueAccountCapabilityController,
+	},
+	EntitlementStorageCapabilities: {
+		EntitlementGetStorageCapabilityController,
+		EntitlementIssueStorageCapabilityController,
+	},
+	EntitlementAccountCapabilities: {
+		EntitlementGetAccountCapabilityController,
+		EntitlementIssueAccountCapabilityController,
+	},
+}
+
+// grants reports whether the declared entitlement grants the required one.
+func grants(declared Entitlement, required Entitlement) bool {
+	return declared == required ||
+		slices.Contains(entitlementGroups[declared], required)
+}
+
+// EntitlementReport compares the entitlements an authorizer declares
+// with the entitlements inferred from the prepare block.
+type EntitlementReport struct {
+	Authorizer string
+	Declared   []Entitlement
+	Inferred   []Entitlement
+	// Missing are inferred entitlements not granted by any declared entitlement.
+	Missing []Entitlement
+	// Unused are declared entitlements that grant none of the inferred entitlements.
+	Unused []Entitlement
+	// Escapes is true if the authorizer reference is used in a way the analysis does not follow,
+	// e.g. passed to a function, or one of its members is bound to a variable, as in let s = signer.storage.
+	// The inferred entitlements may then be incomplete.
+	Escapes bool
+}
+
+// Mismatch reports whether the declared entitlements differ from the inferred ones.
+func (r EntitlementReport) Mismatch() bool {
+	return len(r.Missing) > 0 || len(r.Unused) > 0
+}
+
+func (r EntitlementReport) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf(
+		"%s: declared [%s], inferred [%s]",
+		r.Authorizer,
+		strings.Join(r.Declared, ", "),
+		strings.Join(r.Inferred, ", "),
+	))
+	if len(r.Missing) > 0 {
+		builder.WriteString(fmt.Sprintf(", missing [%s]", strings.Join(r.Missing, ", ")))
+	}
+	if len(r.Unused) > 0 {
+		builder.WriteString(fmt.Sprintf(", unused [%s]", strings.Join(r.Unused, ", ")))
+	}
+	if r.Escapes {
+		builder.WriteString(", escapes")
+	}
+	return builder.String()
+}
+
+type authorizerUsage struct {
+	entitlements []Entitlement
+	escapes      bool
+}
+
+// InferEntitlements parses the prepare block and infers, for each of the named authorizers,
+// the entitlements required by the way it is used.
+func InferEntitlements(prepareBlock string, authorizerNames ...string) (map[string][]Entitlement, error) {
+	usages, err := analyzeAuthorizers(prepareBlock, authorizerNames)
+	if err != nil {
+		return nil, err
+	}
+
+	inferred := make(map[string][]Entitlement, len(usages))
+	for name, usage := range usages {
+		inferred[name] = usage.entitlements
+	}
+	return inferred, nil
+}
+
+// CheckEntitlements compares the declared entitlements of each authorizer of tx
+// with the inferred ones.
+func CheckEntitlements(tx Transaction) ([]EntitlementReport, error) {
+	authorizers := tx.GetAuthorizers()
+	if authorizers == nil {
+		authorizers = DefaultAuthorizers
+	}
+
+	names := make([]string, 0, len(authorizers))
+	for _, authorizer := range authorizers {
+		names = append(names, authorizer.Name)
+	}
+
+	usages, err := analyzeAuthorizers(tx.GetPrepareBlock(), names)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]EntitlementReport, 0, len(authorizers))
+	for _, authorizer := range authorizers {
+		usage := usages[authorizer.Name]
+		report := EntitlementReport{
+			Authorizer: authorizer.Name,
+			Declared:   authorizer.Entitlements,
+			Inferred:   usage.entitlements,
+			Escapes:    usage.escapes,
+		}
+
+		for _, required := range usage.entitlements {
+			granted := slices.ContainsFunc(authorizer.Entitlements, func(declared Entitlement) bool {
+				return grants(declared, required)
+			})
+			if !granted {
+				report.Missing = append(report.Missing, required)
+			}
+		}
+
+		for _, declared := range authorizer.Entitlements {
+			used := slices.ContainsFunc(usage.entitlements, func(required Entitlement) bool {
+				return grants(declared, required)
+			})
+			if !used {
+				report.Unused = append(report.Unused, declared)
+			}
+		}
+
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// templateRegistry is implemented by registries that hold templates, e.g. MapRegistry.
+type templateRegistry interface {
+	GetTemplate(label Label) (Template, error)
+}
+
+// CheckRegistryEntitlements checks the entitlements of all transactions in the registry.
+// It returns the reports of the transactions with mismatches, and the errors of the transactions
+// that could not be checked, e.g. because they do not parse, both by label.
+// Templates with required parameters cannot be constructed from their defaults, and are skipped.
+func CheckRegistryEntitlements(registry Registry) (map[Label][]EntitlementReport, map[Label]error) {
+	mismatches := map[Label][]EntitlementReport{}
+	errs := map[Label]error{}
+	for _, label := range registry.AllLabels() {
+		if hasRequiredParameters(registry, label) {
+			continue
+		}
+
+		tx, err := registry.Get(label)
+		if err != nil {
+			errs[label] = err
+			continue
+		}
+
+		reports, err := CheckEntitlements(tx)
+		if err != nil {
+			errs[label] = fmt.Errorf("failed to check entitlements of %s: %w", label, err)
+			continue
+		}
+		if slices.ContainsFunc(reports, EntitlementReport.Mismatch) {
+			mismatches[label] = reports
+		}
+	}
+	return mismatches, errs
+}
+
+// hasRequiredParameters reports whether label is registered as a template with required parameters.
+func hasRequiredParameters(registry Registry, label Label) bool {
+	templates, ok := registry.(templateRegistry)
+	if !ok {
+		return false
+	}
+	template, err := templates.GetTemplate(label)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(template.Parameters(), func(p Parameter) bool {
+		return p.Default == nil
+	})
+}
+
+func analyzeAuthorizers(prepareBlock string, authorizerNames []string) (map[string]*authorizerUsage, error) {
+	statements, errs := parser.ParseStatements(nil, []byte(prepareBlock), parser.Config{})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to parse prepare block: %w", errors.Join(errs...))
+	}
+
+	usages := make(map[string]*authorizerUsage, len(authorizerNames))
+	for _, name := range authorizerNames {
+		usages[name] = &authorizerUsage{}
+	}
+
+	// authorizer identifiers used as the base of a known member access or as payer
+	handled := map[*ast.IdentifierExpression]bool{}
+	var occurrences []*ast.IdentifierExpression
+
+	require := func(name string, entitlement Entitlement) {
+		usage := usages[name]
+		if !slices.Contains(usage.entitlements, entitlement) {
+			usage.entitlements = append(usage.entitlements, entitlement)
+		}
+	}
+
+	for _, statement := range statements {
+		ast.Inspect(statement, func(element ast.Element) bool {
+			switch expression := element.(type) {
+			case *ast.IdentifierExpression:
+				if _, ok := usages[expression.Identifier.Identifier]; ok {
+					occurrences = append(occurrences, expression)
+				}
+
+			case *ast.MemberExpression:
+				base, path := memberPath(expression)
+				if base == nil {
+					break
+				}
+				name := base.Identifier.Identifier
+				if _, ok := usages[name]; !ok {
+					break
+				}
+				// members are visited outermost first, e.g. signer.storage.save before signer.storage,
+				// so a base that is already handled belongs to a known member path
+				if entitlement, ok := memberEntitlements[path]; ok {
+					handled[base] = true
+					require(name, entitlement)
+				} else if unrestrictedMembers[path] {
+					handled[base] = true
+				}
+
+			case *ast.InvocationExpression:
+				invoked, ok := expression.InvokedExpression.(*ast.IdentifierExpression)
+				if !ok || invoked.Identifier.Identifier != "Account" {
+					break
+				}
+				for _, argument := range expression.Arguments {
+					payer, ok := argument.Expression.(*ast.IdentifierExpression)
+					if argument.Label != "payer" || !ok {
+						continue
+					}
+					if _, ok := usages[payer.Identifier.Identifier]; ok {
+						handled[payer] = true
+						require(payer.Identifier.Identifier, payerEntitlement)
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	for _, occurrence := range occurrences {
+		if !handled[occurrence] {
+			usages[occurrence.Identifier.Identifier].escapes = true
+		}
+	}
+	return usages, nil
+}
+
+// memberPath returns the identifier at the base of a chain of member accesses
+// and the dot-separated path of members, e.g. signer and storage.save for signer.storage.save.
+func memberPath(expression *ast.MemberExpression) (*ast.IdentifierExpression, string) {
+	var members []string
+	var current ast.Expression = expression
+	for {
+		switch e := current.(type) {
+		case *ast.MemberExpression:
+			members = append(members, e.Identifier.Identifier)
+			current = e.Expression
+		case *ast.IdentifierExpression:
+			slices.Reverse(members)
+			return e, strings.Join(members, ".")
+		default:
+			return nil, ""
+		}
+	}
+}
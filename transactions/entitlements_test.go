@@ -0,0 +1,112 @@
+package transactions
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckEntitlements(t *testing.T) {
+	tests := []struct {
+		name     string
+		prepare  string
+		declared []Entitlement
+		missing  []Entitlement
+		unused   []Entitlement
+		escapes  bool
+	}{
+		{
+			name:     "matching",
+			prepare:  `signer.storage.save(1, to: /storage/x)`,
+			declared: []Entitlement{EntitlementSaveValue},
+		},
+		{
+			name:     "granted by group",
+			prepare:  `signer.storage.load<Int>(from: /storage/x)`,
+			declared: []Entitlement{EntitlementStorage},
+		},
+		{
+			name:    "missing",
+			prepare: `signer.keys.revoke(keyIndex: 0)`,
+			missing: []Entitlement{EntitlementRevokeKey},
+		},
+		{
+			name:     "unused",
+			prepare:  `let address = signer.address`,
+			declared: []Entitlement{EntitlementSaveValue},
+			unused:   []Entitlement{EntitlementSaveValue},
+		},
+		{
+			name:    "member bound to variable",
+			prepare: `let storage = signer.storage`,
+			escapes: true,
+		},
+		{
+			name:    "passed to function",
+			prepare: `log(signer)`,
+			escapes: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := NewSimpleTransaction(test.prepare).SetAuthorizers(Signer(test.declared...))
+			reports, err := CheckEntitlements(tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reports) != 1 {
+				t.Fatalf("expected one report, got %d", len(reports))
+			}
+			report := reports[0]
+			if !slices.Equal(report.Missing, test.missing) ||
+				!slices.Equal(report.Unused, test.unused) ||
+				report.Escapes != test.escapes {
+				t.Errorf("unexpected report %s", report)
+			}
+		})
+	}
+}
+
+func TestCheckRegistryEntitlements(t *testing.T) {
+	registry := NewRegistry()
+	register := func(label Label, tx Transaction) {
+		err := registry.Register(label, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	register("Matching", NewSimpleTransaction(`signer.storage.save(1, to: /storage/x)`).
+		SetAuthorizers(Signer(EntitlementSaveValue)))
+	register("Mismatch", NewSimpleTransaction(`signer.storage.save(1, to: /storage/x)`).
+		SetAuthorizers(Signer()))
+	register("Unparsable", NewSimpleTransaction(`while true {`).
+		SetAuthorizers(Signer()))
+
+	err := registry.RegisterTemplate("Required", &FuncTemplate{
+		Schema: Parameters{{Name: "recipient", Type: ParameterTypeString}},
+		Construct: func(values Values) (*SimpleTransaction, error) {
+			t.Error("template with required parameters is constructed")
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = registry.RegisterTemplate("Invalid", &FuncTemplate{
+		Construct: func(values Values) (*SimpleTransaction, error) {
+			return nil, invalidParameter("invalid default")
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mismatches, errs := CheckRegistryEntitlements(registry)
+
+	if len(mismatches) != 1 || mismatches["Mismatch"] == nil {
+		t.Errorf("unexpected mismatches %v", mismatches)
+	}
+	if len(errs) != 2 || errs["Unparsable"] == nil || errs["Invalid"] == nil {
+		t.Errorf("unexpected errors %v", errs)
+	}
+}
@@ -0,0 +1,54 @@
+package transactions
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Error codes reported by the FVM in transaction results.
+const (
+	ErrCodeInvalidProposalSeqNumber = 1007
+	ErrCodeCadenceRuntimeError      = 1101
+	ErrCodeStorageCapacityExceeded  = 1103
+	ErrCodeComputationLimitExceeded = 1110
+	ErrCodeMemoryLimitExceeded      = 1111
+)
+
+var errorCodePattern = regexp.MustCompile(`\[Error Code: (\d+)\]`)
+
+// ErrorCode returns the FVM error code contained in the message of a transaction result error.
+func ErrorCode(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+	match := errorCodePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	code, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return 0, false
+	}
+	return code, true
+}
+
+// ExpectedFailure describes how a transaction is expected to fail.
+type ExpectedFailure struct {
+	// ErrorCode is the FVM error code reported in the transaction result.
+	ErrorCode int
+	// CadenceError is the name of the Cadence error type causing the failure, if any.
+	// It is informational only, as the type name is not part of the result error message.
+	CadenceError string
+	// Message is a substring of the result error message, if not empty.
+	Message string
+}
+
+// Matches reports whether err is a transaction result error matching the expected failure.
+func (f *ExpectedFailure) Matches(err error) bool {
+	code, ok := ErrorCode(err)
+	if !ok || code != f.ErrorCode {
+		return false
+	}
+	return strings.Contains(err.Error(), f.Message)
+}
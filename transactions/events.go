@@ -0,0 +1,63 @@
+package transactions
+
+// Event types emitted by the templates, besides the built-in account events of the flow package.
+// Contract event types omit the address of the contract.
+const (
+	EventSomeEvent                                = "TestContract.SomeEvent"
+	EventSomeEvent2                               = "TestContract.SomeEvent2"
+	EventItemDestroyed                            = "TestContract.Item.ResourceDestroyed"
+	EventTokensWithdrawn                          = "FlowToken.TokensWithdrawn"
+	EventTokensDeposited                          = "FlowToken.TokensDeposited"
+	EventFungibleTokenWithdrawn                   = "FungibleToken.Withdrawn"
+	EventFungibleTokenDeposited                   = "FungibleToken.Deposited"
+	EventFungibleTokenBurned                      = "FungibleToken.Burned"
+	EventStorageCapabilityControllerIssued        = "flow.StorageCapabilityControllerIssued"
+	EventStorageCapabilityControllerDeleted       = "flow.StorageCapabilityControllerDeleted"
+	EventStorageCapabilityControllerTargetChanged = "flow.StorageCapabilityControllerTargetChanged"
+	EventAccountCapabilityControllerIssued        = "flow.AccountCapabilityControllerIssued"
+	EventAccountCapabilityControllerDeleted       = "flow.AccountCapabilityControllerDeleted"
+	EventCapabilityPublished                      = "flow.CapabilityPublished"
+	EventInboxValuePublished                      = "flow.InboxValuePublished"
+	EventInboxValueUnpublished                    = "flow.InboxValueUnpublished"
+	EventInboxValueClaimed                        = "flow.InboxValueClaimed"
+	EventCapabilityUnpublished                    = "flow.CapabilityUnpublished"
+	EventNFTWithdrawn                             = "NonFungibleToken.Withdrawn"
+	EventNFTDeposited                             = "NonFungibleToken.Deposited"
+	EventNFTDestroyed                             = "NonFungibleToken.NFT.ResourceDestroyed"
+)
+
+// ExpectedEvent is an event type a transaction is expected to emit Count times.
+// Only the events emitted by the transaction itself are expected;
+// events emitted for fee deduction and account setup are not.
+type ExpectedEvent struct {
+	Type  string
+	Count uint64
+}
+
+// mergeExpectedEvents sums the counts of the expected events by type,
+// in order of first appearance.
+func mergeExpectedEvents(lists ...[]ExpectedEvent) []ExpectedEvent {
+	var merged []ExpectedEvent
+	indices := map[string]int{}
+	for _, events := range lists {
+		for _, event := range events {
+			index, ok := indices[event.Type]
+			if !ok {
+				indices[event.Type] = len(merged)
+				merged = append(merged, event)
+				continue
+			}
+			merged[index].Count += event.Count
+		}
+	}
+	return merged
+}
+
+func flowTokenTransferEvents(count uint64) []ExpectedEvent {
+	return []ExpectedEvent{
+		{Type: EventTokensWithdrawn, Count: count},
+		{Type: EventFungibleTokenWithdrawn, Count: count},
+		{Type: EventTokensDeposited, Count: count},
+		{Type: EventFungibleTokenDeposited, Count: count},
+	}
+}
@@ -0,0 +1,184 @@
+package transactions
+
+import (
+	"fmt"
+)
+
+// FAILING TRANSACTIONS
+
+// FailureKind is a way a transaction is made to fail.
+type FailureKind string
+
+const (
+	FailurePanic                      FailureKind = "Panic"
+	FailureOverflow                   FailureKind = "Overflow"
+	FailureForceUnwrapNil             FailureKind = "ForceUnwrapNil"
+	FailureAssert                     FailureKind = "Assert"
+	FailurePreCondition               FailureKind = "PreCondition"
+	FailureInvalidCast                FailureKind = "InvalidCast"
+	FailureDestroyedResourceReference FailureKind = "DestroyedResourceReference"
+)
+
+// FailureKinds lists all failure kinds in catalog order.
+var FailureKinds = []FailureKind{
+	FailurePanic,
+	FailureOverflow,
+	FailureForceUnwrapNil,
+	FailureAssert,
+	FailurePreCondition,
+	FailureInvalidCast,
+	FailureDestroyedResourceReference,
+}
+
+// Placement is the transaction block a failing body is placed in.
+type Placement string
+
+const (
+	PlacementPrepare Placement = "Prepare"
+	PlacementExecute Placement = "Execute"
+)
+
+var Placements = []Placement{
+	PlacementPrepare,
+	PlacementExecute,
+}
+
+type failingBody struct {
+	body            string
+	expectedFailure ExpectedFailure
+}
+
+var failingBodies = map[FailureKind]failingBody{
+	FailurePanic: {
+		body: `panic("expected failure")`,
+		expectedFailure: ExpectedFailure{
+			ErrorCode:    ErrCodeCadenceRuntimeError,
+			CadenceError: "PanicError",
+			Message:      "panic: expected failure",
+		},
+	},
+	FailureOverflow: {
+		body: `
+			let x: UInt8 = 255
+			let y = x + 1
+		`,
+		expectedFailure: ExpectedFailure{
+			ErrorCode:    ErrCodeCadenceRuntimeError,
+			CadenceError: "OverflowError",
+			Message:      "overflow",
+		},
+	},
+	FailureForceUnwrapNil: {
+		body: `
+			let x: Int? = nil
+			let y = x!
+		`,
+		expectedFailure: ExpectedFailure{
+			ErrorCode:    ErrCodeCadenceRuntimeError,
+			CadenceError: "ForceNilError",
+			Message:      "unexpectedly found nil while forcing an Optional value",
+		},
+	},
+	FailureAssert: {
+		body: `assert(false, message: "expected failure")`,
+		expectedFailure: ExpectedFailure{
+			ErrorCode:    ErrCodeCadenceRuntimeError,
+			CadenceError: "AssertionError",
+			Message:      "assertion failed: expected failure",
+		},
+	},
+	FailurePreCondition: {
+		body: `
+			fun positive(_ x: Int) {
+				pre {
+					x > 0: "expected failure"
+				}
+			}
+			positive(0)
+		`,
+		expectedFailure: ExpectedFailure{
+			ErrorCode:    ErrCodeCadenceRuntimeError,
+			CadenceError: "ConditionError",
+			Message:      "pre-condition failed: expected failure",
+		},
+	},
+	FailureInvalidCast: {
+		body: `
+			let x: AnyStruct = 1
+			let y = x as! String
+		`,
+		expectedFailure: ExpectedFailure{
+			ErrorCode:    ErrCodeCadenceRuntimeError,
+			CadenceError: "ForceCastTypeMismatchError",
+			Message:      "failed to force-cast value",
+		},
+	},
+	FailureDestroyedResourceReference: {
+		// the reference is passed through a function, so the checker cannot tell
+		// that it refers to the destroyed resource, and only the interpreter rejects its use
+		body: `
+			fun reference(_ ref: &{String: AnyResource}): &{String: AnyResource} {
+				return ref
+			}
+			let r: @{String: AnyResource} <- {}
+			let ref = reference(&r as &{String: AnyResource})
+			destroy r
+			ref.length
+		`,
+		expectedFailure: ExpectedFailure{
+			ErrorCode:    ErrCodeCadenceRuntimeError,
+			CadenceError: "InvalidatedResourceReferenceError",
+			Message:      "referenced resource has been moved or destroyed",
+		},
+	},
+}
+
+// FailingTransaction returns a transaction that fails in the given way,
+// with the failing code placed in the given block.
+var FailingTransaction = func(kind FailureKind, placement Placement) *SimpleTransaction {
+	return must(NewFailingTransaction(kind, placement))
+}
+
+func NewFailingTransaction(kind FailureKind, placement Placement) (*SimpleTransaction, error) {
+	failing, ok := failingBodies[kind]
+	if !ok {
+		return nil, invalidParameter("unknown failure kind: %s", kind)
+	}
+	expectedFailure := failing.expectedFailure
+
+	switch placement {
+	case PlacementPrepare:
+		return NewSimpleTransaction(failing.body).
+			SetAuthorizers(Signer()).
+			SetExpectedFailure(&expectedFailure), nil
+	case PlacementExecute:
+		return NewSimpleTransaction("").
+			SetExecuteBlock(failing.body).
+			SetAuthorizers(Signer()).
+			SetExpectedFailure(&expectedFailure), nil
+	default:
+		return nil, invalidParameter("unknown placement: %s", placement)
+	}
+}
+
+// FailingTransactionLabel returns the label a failing transaction is registered under,
+// e.g. FailPanicInPrepare.
+func FailingTransactionLabel(kind FailureKind, placement Placement) Label {
+	return fmt.Sprintf("Fail%sIn%s", kind, placement)
+}
+
+// RegisterFailingTransactions registers every failure kind in every placement.
+func RegisterFailingTransactions(registry *MapRegistry) error {
+	for _, kind := range FailureKinds {
+		for _, placement := range Placements {
+			err := registry.Register(
+				FailingTransactionLabel(kind, placement),
+				FailingTransaction(kind, placement),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package transactions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+var nextTransactionLocation = runtime_utils.NewTransactionLocationGenerator()
+
+// executeTransaction renders tx and executes it with the Cadence runtime, signed by a single account.
+// Transactions must not import contracts.
+func executeTransaction(tx Transaction) error {
+	runtimeInterface := &runtime_utils.TestRuntimeInterface{
+		Storage: runtime_utils.NewTestLedger(nil, nil),
+		OnGetSigningAccounts: func() ([]common.Address, error) {
+			return []common.Address{{42}}, nil
+		},
+	}
+	return runtime_utils.NewTestRuntime().ExecuteTransaction(
+		runtime.Script{Source: []byte(Render(tx, nil))},
+		runtime.Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+}
+
+// TestFailingTransactionsMatchExpectedFailures executes every failing transaction with the Cadence runtime,
+// and checks that the resulting error, wrapped as the FVM reports it, matches the expected failure.
+func TestFailingTransactionsMatchExpectedFailures(t *testing.T) {
+	for _, kind := range FailureKinds {
+		for _, placement := range Placements {
+			t.Run(FailingTransactionLabel(kind, placement), func(t *testing.T) {
+				tx, err := NewFailingTransaction(kind, placement)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = executeTransaction(tx)
+				if err == nil {
+					t.Fatal("transaction did not fail")
+				}
+
+				resultErr := fmt.Errorf("[Error Code: %d] cadence runtime error: %w", ErrCodeCadenceRuntimeError, err)
+				if !tx.GetExpectedFailure().Matches(resultErr) {
+					t.Errorf("expected failure %+v does not match error: %s", *tx.GetExpectedFailure(), err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package transactions
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/flow-go-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// EmbeddedTemplates are the file templates shipped with the module.
+//
+//go:embed templates/*.cdc
+var EmbeddedTemplates embed.FS
+
+const frontMatterDelimiter = "---"
+
+// FileTemplate is a template loaded from a .cdc file.
+//
+// The file optionally starts with a YAML front-matter between --- lines,
+// declaring the label (defaults to the file name without extension),
+// a description and the parameters:
+//
+//	---
+//	description: Runs an empty loop.
+//	parameters:
+//	  - name: loopLength
+//	    type: uint64
+//	    default: 10
+//	    max: 100000
+//	---
+//	transaction {
+//	    prepare(signer: &Account) {
+//	        var i = 0
+//	        while i < {{ .loopLength }} {
+//	            i = i + 1
+//	        }
+//	    }
+//	}
+//
+// The rest of the file is a Go text/template of a Cadence transaction, executed with the resolved
+// parameter values. The transaction must not have parameters; the authorizers and their entitlements
+// are taken from the prepare block parameters. Contracts of the imports passed to Render need not be imported.
+// Other contracts are imported from an address (import Foo from 0x01) or by name (import Crypto),
+// and Render adds them to its imports. String imports and aliases are not supported.
+type FileTemplate struct {
+	Label       Label      `yaml:"label"`
+	Description string     `yaml:"description"`
+	Schema      Parameters `yaml:"parameters"`
+
+	template *template.Template
+}
+
+var _ Template = (*FileTemplate)(nil)
+
+// templateFuncs are available in file templates in addition to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"repeat":  strings.Repeat,
+	"ufix64":  ufix64,
+	"literal": CadenceLiteral,
+}
+
+// ParseFileTemplate parses the contents of the template file with the given name.
+func ParseFileTemplate(name string, data []byte) (*FileTemplate, error) {
+	fileTemplate := &FileTemplate{}
+
+	body := string(data)
+	if frontMatter, rest, ok := splitFrontMatter(body); ok {
+		err := yaml.Unmarshal([]byte(frontMatter), fileTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse front-matter of %s: %w", name, err)
+		}
+		body = rest
+	}
+	if fileTemplate.Label == "" {
+		fileTemplate.Label = strings.TrimSuffix(path.Base(name), path.Ext(name))
+	}
+
+	err := fileTemplate.Schema.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of %s: %w", name, err)
+	}
+
+	fileTemplate.template, err = template.New(name).
+		Funcs(templateFuncs).
+		Option("missingkey=error").
+		Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	return fileTemplate, nil
+}
+
+// splitFrontMatter splits the front-matter off the file contents, if the file has one.
+func splitFrontMatter(data string) (frontMatter string, rest string, ok bool) {
+	first, after, found := strings.Cut(data, "\n")
+	if !found || strings.TrimSpace(first) != frontMatterDelimiter {
+		return "", data, false
+	}
+
+	lines := strings.SplitAfter(after, "\n")
+	offset := 0
+	for _, line := range lines {
+		if strings.TrimSpace(line) == frontMatterDelimiter {
+			return after[:offset], after[offset+len(line):], true
+		}
+		offset += len(line)
+	}
+	return "", data, false
+}
+
+// LoadFileTemplates loads all .cdc files in dir of fsys, in lexical order.
+func LoadFileTemplates(fsys fs.FS, dir string) ([]*FileTemplate, error) {
+	names, err := fs.Glob(fsys, path.Join(dir, "*.cdc"))
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*FileTemplate, 0, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		fileTemplate, err := ParseFileTemplate(name, data)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, fileTemplate)
+	}
+	return templates, nil
+}
+
+// LoadFileTemplatesFromDir loads all .cdc files in the directory dir.
+func LoadFileTemplatesFromDir(dir string) ([]*FileTemplate, error) {
+	return LoadFileTemplates(os.DirFS(dir), ".")
+}
+
+// RegisterFileTemplates registers the templates under their labels.
+func RegisterFileTemplates(registry *MapRegistry, templates ...*FileTemplate) error {
+	for _, fileTemplate := range templates {
+		err := registry.RegisterTemplate(fileTemplate.Label, fileTemplate)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterEmbeddedTemplates registers the file templates shipped with the module.
+func RegisterEmbeddedTemplates(registry *MapRegistry) error {
+	templates, err := LoadFileTemplates(EmbeddedTemplates, "templates")
+	if err != nil {
+		return err
+	}
+	return RegisterFileTemplates(registry, templates...)
+}
+
+func (t *FileTemplate) Parameters() Parameters {
+	return t.Schema
+}
+
+// New executes the template with the resolved values and parses the resulting transaction.
+func (t *FileTemplate) New(values map[string]any) (Transaction, error) {
+	resolved, err := t.Schema.Resolve(values)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", t.Label, err)
+	}
+
+	buffer := bytes.Buffer{}
+	err = t.template.Execute(&buffer, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", t.Label, err)
+	}
+
+	tx, err := parseTransaction(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", t.Label, err)
+	}
+	return tx, nil
+}
+
+// parseTransaction splits the Cadence source code of a transaction into its blocks.
+func parseTransaction(code []byte) (*SimpleTransaction, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction: %w", err)
+	}
+
+	for _, declaration := range program.Declarations() {
+		switch declaration.(type) {
+		case *ast.TransactionDeclaration, *ast.ImportDeclaration:
+		default:
+			return nil, fmt.Errorf("unexpected declaration %s", declaration.DeclarationIdentifier())
+		}
+	}
+
+	imports, err := parseImports(program.ImportDeclarations())
+	if err != nil {
+		return nil, err
+	}
+
+	declarations := program.TransactionDeclarations()
+	if len(declarations) != 1 {
+		return nil, fmt.Errorf("expected one transaction, found %d", len(declarations))
+	}
+	declaration := declarations[0]
+
+	if declaration.ParameterList != nil && len(declaration.ParameterList.Parameters) > 0 {
+		return nil, errors.New("transaction parameters are not supported")
+	}
+
+	source := string(code)
+	tx := NewSimpleTransaction("").SetImports(imports)
+
+	if len(declaration.Fields) > 0 {
+		fields := make([]string, 0, len(declaration.Fields))
+		for _, field := range declaration.Fields {
+			fields = append(fields, source[field.StartPos.Offset:field.EndPos.Offset+1])
+		}
+		tx.SetFieldDeclarations(strings.Join(fields, "\n"))
+	}
+
+	authorizers := []Authorizer{}
+	if declaration.Prepare != nil {
+		function := declaration.Prepare.FunctionDeclaration
+		tx.SetPrepareBlock(blockBody(source, function.FunctionBlock.Block.Range))
+
+		if function.ParameterList != nil {
+			for _, parameter := range function.ParameterList.Parameters {
+				authorizer, err := parseAuthorizer(parameter)
+				if err != nil {
+					return nil, err
+				}
+				authorizers = append(authorizers, authorizer)
+			}
+		}
+	}
+	tx.SetAuthorizers(authorizers...)
+
+	if declaration.Execute != nil {
+		tx.SetExecuteBlock(blockBody(source, declaration.Execute.FunctionDeclaration.FunctionBlock.Block.Range))
+	}
+	if declaration.PreConditions != nil {
+		tx.SetPreConditions(blockBody(source, declaration.PreConditions.Range))
+	}
+	if declaration.PostConditions != nil {
+		tx.SetPostConditions(blockBody(source, declaration.PostConditions.Range))
+	}
+	return tx, nil
+}
+
+// parseImports returns the contracts imported by the declarations.
+// It returns an error for imports that Render cannot reproduce.
+func parseImports(declarations []*ast.ImportDeclaration) (Imports, error) {
+	var imports Imports
+	add := func(name string, address flow.Address) error {
+		if existing, ok := imports[name]; ok && existing != address {
+			return fmt.Errorf(
+				"conflicting imports of contract %s: %s and %s",
+				name,
+				existing.HexWithPrefix(),
+				address.HexWithPrefix(),
+			)
+		}
+		if imports == nil {
+			imports = Imports{}
+		}
+		imports[name] = address
+		return nil
+	}
+
+	for _, declaration := range declarations {
+		switch location := declaration.Location.(type) {
+		case common.AddressLocation:
+			address := flow.BytesToAddress(location.Address.Bytes())
+			for _, imported := range declaration.Imports {
+				if imported.Alias.Identifier != "" {
+					return nil, fmt.Errorf(
+						"unsupported alias %s of imported contract %s",
+						imported.Alias.Identifier,
+						imported.Identifier.Identifier,
+					)
+				}
+				err := add(imported.Identifier.Identifier, address)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case common.IdentifierLocation:
+			if len(declaration.Imports) > 0 {
+				return nil, fmt.Errorf("unsupported import from %s", location)
+			}
+			err := add(string(location), flow.EmptyAddress)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf(
+				"unsupported import from %s: import contracts from an address or by name",
+				declaration.Location,
+			)
+		}
+	}
+	return imports, nil
+}
+
+// blockBody returns the source code between the braces of the block in the range,
+// which may start with a keyword, e.g. pre.
+func blockBody(source string, r ast.Range) string {
+	block := source[r.StartPos.Offset : r.EndPos.Offset+1]
+	start := strings.IndexRune(block, '{')
+	return strings.TrimRight(block[start+1:len(block)-1], " \t")
+}
+
+// parseAuthorizer parses a prepare block parameter of type &Account or auth(...) &Account.
+func parseAuthorizer(parameter *ast.Parameter) (Authorizer, error) {
+	name := parameter.Identifier.Identifier
+
+	reference, ok := parameter.TypeAnnotation.Type.(*ast.ReferenceType)
+	if !ok {
+		return Authorizer{}, fmt.Errorf("prepare parameter %s is not an account reference", name)
+	}
+	referenced, ok := reference.Type.(*ast.NominalType)
+	if !ok || referenced.Identifier.Identifier != "Account" || len(referenced.NestedIdentifiers) > 0 {
+		return Authorizer{}, fmt.Errorf("prepare parameter %s is not an account reference", name)
+	}
+
+	authorizer := Authorizer{Name: name}
+	switch authorization := reference.Authorization.(type) {
+	case nil:
+	case *ast.ConjunctiveEntitlementSet:
+		for _, entitlement := range authorization.Entitlements() {
+			authorizer.Entitlements = append(authorizer.Entitlements, entitlement.String())
+		}
+	default:
+		return Authorizer{}, fmt.Errorf(
+			"unsupported authorization of prepare parameter %s: %s",
+			name,
+			authorization,
+		)
+	}
+	return authorizer, nil
+}
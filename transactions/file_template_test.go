@@ -0,0 +1,121 @@
+package transactions
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestFileTemplateImports(t *testing.T) {
+	fileTemplate, err := ParseFileTemplate("imports.cdc", []byte(`
+		import Foo, Bar from 0x01
+		import Crypto
+
+		transaction {
+			prepare(signer: &Account) {
+				Foo.foo()
+				Bar.bar()
+				Crypto.KeyListEntry
+				TestContract.test()
+			}
+		}
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := fileTemplate.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Imports{
+		"Foo":    flow.HexToAddress("0x01"),
+		"Bar":    flow.HexToAddress("0x01"),
+		"Crypto": flow.EmptyAddress,
+	}
+	if !maps.Equal(tx.GetImports(), expected) {
+		t.Errorf("unexpected imports %v", tx.GetImports())
+	}
+
+	// the imports passed to Render take precedence
+	rendered := Render(tx, Imports{
+		"Bar":          flow.HexToAddress("0x02"),
+		"TestContract": flow.HexToAddress("0x03"),
+	})
+	for _, line := range []string{
+		"import Foo from 0x0000000000000001\n",
+		"import Bar from 0x0000000000000002\n",
+		"import Crypto\n",
+		"import TestContract from 0x0000000000000003\n",
+	} {
+		if !strings.Contains(rendered, line) {
+			t.Errorf("rendered transaction does not contain %q:\n%s", line, rendered)
+		}
+	}
+}
+
+func TestFileTemplateUnsupportedImports(t *testing.T) {
+	for name, test := range map[string]struct {
+		imports string
+		err     string
+	}{
+		"string": {
+			imports: `import "Foo"`,
+			err:     "unsupported import from Foo",
+		},
+		"alias": {
+			imports: `import Foo as Bar from 0x01`,
+			err:     "unsupported alias Bar of imported contract Foo",
+		},
+		"conflicting addresses": {
+			imports: "import Foo from 0x01\nimport Foo from 0x02",
+			err:     "conflicting imports of contract Foo",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fileTemplate, err := ParseFileTemplate("imports.cdc", []byte(test.imports+"\ntransaction {}\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = fileTemplate.New(nil)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestCompositeTransactionImports(t *testing.T) {
+	foo := NewSimpleTransaction(`Foo.foo()`).SetImports(Imports{"Foo": flow.HexToAddress("0x01")})
+	bar := NewSimpleTransaction(`Bar.bar()`).SetImports(Imports{"Bar": flow.HexToAddress("0x02")})
+
+	composite, err := NewCompositeTransaction(foo, bar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Imports{
+		"Foo": flow.HexToAddress("0x01"),
+		"Bar": flow.HexToAddress("0x02"),
+	}
+	if !maps.Equal(composite.Simple().GetImports(), expected) {
+		t.Errorf("unexpected imports %v", composite.Simple().GetImports())
+	}
+
+	conflicting := NewSimpleTransaction(`Foo.foo()`).SetImports(Imports{"Foo": flow.HexToAddress("0x03")})
+	_, err = NewCompositeTransaction(foo, conflicting)
+	if err == nil {
+		t.Error("expected error for conflicting imports")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for conflicting additional imports")
+		}
+		if !maps.Equal(composite.GetImports(), expected) {
+			t.Errorf("conflicting imports modified the composite: %v", composite.GetImports())
+		}
+	}()
+	composite.SetImports(Imports{"Bar": flow.HexToAddress("0x03")})
+}
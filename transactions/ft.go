@@ -0,0 +1,144 @@
+package transactions
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// FUNGIBLE TOKEN TRANSACTIONS
+
+// ftContract is the TestToken contract, a FungibleToken with an unrestricted minter,
+// so token throughput can be measured independently of FLOW and its fees.
+//
+//go:embed ft_contract.cdc
+var ftContract []byte
+
+const testTokenVault = `
+	let vault = signer.storage.borrow<auth(FungibleToken.Withdraw) &TestToken.Vault>(
+		from: TestToken.VaultStoragePath
+	) ?? panic("signer has no TestToken vault")
+`
+
+// SetupTestTokenVaultTransaction saves an empty TestToken vault to the signer's storage
+// and publishes a capability to it, unless the signer already has one.
+var SetupTestTokenVaultTransaction = NewSimpleTransaction(
+	`
+		if signer.storage.type(at: TestToken.VaultStoragePath) == nil {
+			signer.storage.save(<- TestToken.createEmptyVault(vaultType: Type<@TestToken.Vault>()), to: TestToken.VaultStoragePath)
+
+			let cap = signer.capabilities.storage.issue<&TestToken.Vault>(TestToken.VaultStoragePath)
+			signer.capabilities.publish(cap, at: TestToken.VaultPublicPath)
+		}
+	`,
+).SetAuthorizers(Signer(
+	EntitlementSaveValue,
+	EntitlementIssueStorageCapabilityController,
+	EntitlementPublishCapability,
+))
+
+// MintTestTokensTransaction mints amount tokens into the signer's vault loopLength times.
+// The amount is given in the smallest UFix64 unit.
+var MintTestTokensTransaction = func(loopLength uint64, amount uint64) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				let vault = signer.capabilities.borrow<&TestToken.Vault>(TestToken.VaultPublicPath)
+					?? panic("signer has no TestToken vault")
+				%s
+			`,
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(`vault.deposit(from: <- TestToken.mintTokens(amount: %s))`, ufix64(amount)),
+			),
+		),
+	).SetAuthorizers(Signer()).
+		SetExpectedEvents(ExpectedEvent{Type: EventFungibleTokenDeposited, Count: loopLength})
+}
+
+// TransferTestTokensTransaction transfers amount tokens from the signer's vault to recipient
+// loopLength times. The amount is given in the smallest UFix64 unit.
+var TransferTestTokensTransaction = func(loopLength uint64, recipient flow.Address, amount uint64) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				%s
+				let receiver = getAccount(%s).capabilities.borrow<&{FungibleToken.Receiver}>(TestToken.VaultPublicPath)
+					?? panic("recipient has no TestToken vault")
+				%s
+			`,
+			testTokenVault,
+			recipient.HexWithPrefix(),
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(`receiver.deposit(from: <- vault.withdraw(amount: %s))`, ufix64(amount)),
+			),
+		),
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(
+			ExpectedEvent{Type: EventFungibleTokenWithdrawn, Count: loopLength},
+			ExpectedEvent{Type: EventFungibleTokenDeposited, Count: loopLength},
+		)
+}
+
+// BatchTransferTestTokensTransaction transfers amount tokens from the signer's vault
+// to each of the recipients. The amount is given in the smallest UFix64 unit.
+var BatchTransferTestTokensTransaction = func(recipients []flow.Address, amount uint64) *SimpleTransaction {
+	return must(NewBatchTransferTestTokensTransaction(recipients, amount))
+}
+
+func NewBatchTransferTestTokensTransaction(recipients []flow.Address, amount uint64) (*SimpleTransaction, error) {
+	if len(recipients) == 0 {
+		return nil, invalidParameter("recipients must not be empty")
+	}
+
+	addresses := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		addresses = append(addresses, recipient.HexWithPrefix())
+	}
+
+	return checkSize(NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				%s
+				let recipients: [Address] = [%s]
+				for recipient in recipients {
+					let receiver = getAccount(recipient).capabilities.borrow<&{FungibleToken.Receiver}>(TestToken.VaultPublicPath)
+						?? panic("recipient has no TestToken vault")
+					receiver.deposit(from: <- vault.withdraw(amount: %s))
+				}
+			`,
+			testTokenVault,
+			strings.Join(addresses, ", "),
+			ufix64(amount),
+		),
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(
+			ExpectedEvent{Type: EventFungibleTokenWithdrawn, Count: uint64(len(recipients))},
+			ExpectedEvent{Type: EventFungibleTokenDeposited, Count: uint64(len(recipients))},
+		))
+}
+
+// BurnTestTokensTransaction withdraws amount tokens from the signer's vault and burns them,
+// loopLength times. The amount is given in the smallest UFix64 unit.
+var BurnTestTokensTransaction = func(loopLength uint64, amount uint64) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				%s
+				%s
+			`,
+			testTokenVault,
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(`TestToken.burnTokens(<- vault.withdraw(amount: %s))`, ufix64(amount)),
+			),
+		),
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(
+			ExpectedEvent{Type: EventFungibleTokenWithdrawn, Count: loopLength},
+			ExpectedEvent{Type: EventFungibleTokenBurned, Count: loopLength},
+		)
+}
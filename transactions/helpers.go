@@ -1,8 +1,11 @@
 package transactions
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
 )
 
 func stringOfLen(length uint64) string {
@@ -37,6 +40,12 @@ func stringDictOfLen(dictLen uint64, stringLen uint64) string {
 	return builder.String()
 }
 
+// stringDictEntrySize is an upper bound of the size of an entry of stringDictOfLen:
+// the key and value strings, the key suffix of at most 20 digits, quotes, colon and comma.
+func stringDictEntrySize(stringLen uint64) uint64 {
+	return 2*stringLen + 26
+}
+
 func simpleTransactionWithLoop(
 	initialLoopLength uint64,
 	body string,
@@ -45,3 +54,41 @@ func simpleTransactionWithLoop(
 		LoopTemplate(initialLoopLength, body),
 	)
 }
+
+// ufix64 formats an amount given in the smallest UFix64 unit as a Cadence UFix64 literal.
+func ufix64(amount uint64) string {
+	return fmt.Sprintf("%d.%08d", amount/100_000_000, amount%100_000_000)
+}
+
+// cadenceSignatureAlgorithm returns the name of the Cadence SignatureAlgorithm case for algo.
+func cadenceSignatureAlgorithm(algo crypto.SignatureAlgorithm) (string, error) {
+	switch algo {
+	case crypto.ECDSA_P256:
+		return "ECDSA_P256", nil
+	case crypto.ECDSA_secp256k1:
+		return "ECDSA_secp256k1", nil
+	case crypto.BLS_BLS12_381:
+		return "BLS_BLS12_381", nil
+	default:
+		return "", invalidParameter("unsupported signature algorithm: %s", algo)
+	}
+}
+
+// cadenceHashAlgorithm returns the name of the Cadence HashAlgorithm case for algo,
+// which must be valid for account keys.
+func cadenceHashAlgorithm(algo crypto.HashAlgorithm) (string, error) {
+	switch algo {
+	case crypto.SHA2_256:
+		return "SHA2_256", nil
+	case crypto.SHA2_384:
+		return "SHA2_384", nil
+	case crypto.SHA3_256:
+		return "SHA3_256", nil
+	case crypto.SHA3_384:
+		return "SHA3_384", nil
+	case crypto.Keccak256:
+		return "KECCAK_256", nil
+	default:
+		return "", invalidParameter("unsupported hash algorithm: %s", algo)
+	}
+}
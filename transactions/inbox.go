@@ -0,0 +1,155 @@
+package transactions
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// INBOX TRANSACTIONS
+
+// The inbox transactions are run by two parties: a provider publishes capabilities to a recipient,
+// which claims them. The capabilities are published under the names AStInbox1, AStInbox2, etc.
+// and target an Int stored at inboxTargetPath.
+const (
+	inboxNamePrefix = "AStInbox"
+	inboxTargetPath = "/storage/AStInboxTarget"
+)
+
+// inboxName is the Cadence expression of the name of the i-th published capability.
+var inboxName = fmt.Sprintf(`"%s".concat(i.toString())`, inboxNamePrefix)
+
+// PublishInboxCapabilitiesTransaction issues numCapabilities capabilities
+// and publishes them to the inbox of recipient.
+// Capabilities published earlier under the same names are replaced.
+var PublishInboxCapabilitiesTransaction = func(numCapabilities uint64, recipient flow.Address) *SimpleTransaction {
+	return must(NewPublishInboxCapabilitiesTransaction(numCapabilities, recipient))
+}
+
+func NewPublishInboxCapabilitiesTransaction(numCapabilities uint64, recipient flow.Address) (*SimpleTransaction, error) {
+	err := checkNonZero("numCapabilities", numCapabilities)
+	if err != nil {
+		return nil, err
+	}
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				if signer.storage.type(at: %[1]s) == nil {
+					signer.storage.save(1, to: %[1]s)
+				}
+				%[2]s
+			`,
+			inboxTargetPath,
+			LoopTemplate(
+				numCapabilities,
+				fmt.Sprintf(
+					`
+						let cap = signer.capabilities.storage.issue<&Int>(%s)
+						signer.inbox.publish(cap, name: %s, recipient: %s)
+					`,
+					inboxTargetPath,
+					inboxName,
+					recipient.HexWithPrefix(),
+				),
+			),
+		),
+	).SetAuthorizers(Signer(
+		EntitlementSaveValue,
+		EntitlementIssueStorageCapabilityController,
+		EntitlementPublishInboxCapability,
+	)).SetExpectedEvents(
+		ExpectedEvent{Type: EventStorageCapabilityControllerIssued, Count: numCapabilities},
+		ExpectedEvent{Type: EventInboxValuePublished, Count: numCapabilities},
+	), nil
+}
+
+// ClaimInboxCapabilitiesTransaction claims numCapabilities capabilities published by provider
+// with PublishInboxCapabilitiesTransaction, and borrows each.
+var ClaimInboxCapabilitiesTransaction = func(numCapabilities uint64, provider flow.Address) *SimpleTransaction {
+	return must(NewClaimInboxCapabilitiesTransaction(numCapabilities, provider))
+}
+
+func NewClaimInboxCapabilitiesTransaction(numCapabilities uint64, provider flow.Address) (*SimpleTransaction, error) {
+	err := checkNonZero("numCapabilities", numCapabilities)
+	if err != nil {
+		return nil, err
+	}
+	return simpleTransactionWithLoop(
+		numCapabilities,
+		fmt.Sprintf(
+			`
+				let cap = signer.inbox.claim<&Int>(%s, provider: %s)
+					?? panic("no capability published to signer")
+				let value = cap.borrow() ?? panic("claimed capability is invalid")
+			`,
+			inboxName,
+			provider.HexWithPrefix(),
+		),
+	).SetAuthorizers(Signer(EntitlementClaimInboxCapability)).
+		SetExpectedEvents(ExpectedEvent{Type: EventInboxValueClaimed, Count: numCapabilities}), nil
+}
+
+// TeardownInboxCapabilitiesTransaction unpublishes the numCapabilities capabilities
+// published with PublishInboxCapabilitiesTransaction that were not claimed,
+// and deletes the controllers of all published capabilities, claimed or not.
+// Run it as the provider between rounds, so the provider's storage does not grow.
+var TeardownInboxCapabilitiesTransaction = func(numCapabilities uint64) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				%s
+				for controller in signer.capabilities.storage.getControllers(forPath: %s) {
+					controller.delete()
+				}
+			`,
+			LoopTemplate(
+				numCapabilities,
+				fmt.Sprintf(`signer.inbox.unpublish<&Int>(%s)`, inboxName),
+			),
+			inboxTargetPath,
+		),
+	).SetAuthorizers(Signer(
+		EntitlementUnpublishInboxCapability,
+		EntitlementGetStorageCapabilityController,
+	))
+}
+
+// PublishAndUnpublishInboxCapabilityTransaction publishes a capability to recipient
+// and unpublishes it again, loopLength times. It only needs the provider to sign.
+var PublishAndUnpublishInboxCapabilityTransaction = func(loopLength uint64, recipient flow.Address) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				if signer.storage.type(at: %[1]s) == nil {
+					signer.storage.save(1, to: %[1]s)
+				}
+				let cap = signer.capabilities.storage.issue<&Int>(%[1]s)
+				%[2]s
+				signer.capabilities.storage.getController(byCapabilityID: cap.id)!.delete()
+			`,
+			inboxTargetPath,
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(
+					`
+						signer.inbox.publish(cap, name: %[1]s, recipient: %[2]s)
+						signer.inbox.unpublish<&Int>(%[1]s)
+					`,
+					inboxName,
+					recipient.HexWithPrefix(),
+				),
+			),
+		),
+	).SetAuthorizers(Signer(
+		EntitlementSaveValue,
+		EntitlementIssueStorageCapabilityController,
+		EntitlementGetStorageCapabilityController,
+		EntitlementPublishInboxCapability,
+		EntitlementUnpublishInboxCapability,
+	)).SetExpectedEvents(
+		ExpectedEvent{Type: EventStorageCapabilityControllerIssued, Count: 1},
+		ExpectedEvent{Type: EventInboxValuePublished, Count: loopLength},
+		ExpectedEvent{Type: EventInboxValueUnpublished, Count: loopLength},
+		ExpectedEvent{Type: EventStorageCapabilityControllerDeleted, Count: 1},
+	)
+}
@@ -0,0 +1,193 @@
+package transactions
+
+import (
+	"fmt"
+	"math"
+)
+
+// LIMIT TRANSACTIONS
+
+// StorageFillTransaction replaces the string stored at /storage/AStFill with a string of fillLen bytes.
+var StorageFillTransaction = func(fillLen uint64) *SimpleTransaction {
+	return must(NewStorageFillTransaction(fillLen))
+}
+
+func NewStorageFillTransaction(fillLen uint64) (*SimpleTransaction, error) {
+	err := checkLength("fillLen", fillLen, 1)
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				signer.storage.load<String>(from: /storage/AStFill)
+				signer.storage.save("%s", to: /storage/AStFill)
+			`,
+			stringOfLen(fillLen),
+		),
+	).SetAuthorizers(Signer(EntitlementLoadValue, EntitlementSaveValue)))
+}
+
+// StorageBelowCapacityTransaction fills the signer's storage up to margin bytes below its capacity.
+// storageUsed and storageCapacity must be read from the signer's account while nothing is stored
+// at /storage/AStFill. The margin has to cover the encoding overhead of the stored string.
+var StorageBelowCapacityTransaction = func(
+	storageUsed uint64,
+	storageCapacity uint64,
+	margin uint64,
+) *SimpleTransaction {
+	return must(NewStorageBelowCapacityTransaction(storageUsed, storageCapacity, margin))
+}
+
+func NewStorageBelowCapacityTransaction(
+	storageUsed uint64,
+	storageCapacity uint64,
+	margin uint64,
+) (*SimpleTransaction, error) {
+	if storageUsed+margin > storageCapacity {
+		return nil, invalidParameter(
+			"storage used %d plus margin %d exceeds capacity %d",
+			storageUsed,
+			margin,
+			storageCapacity,
+		)
+	}
+	return NewStorageFillTransaction(storageCapacity - storageUsed - margin)
+}
+
+// StorageAboveCapacityTransaction fills the signer's storage up to margin bytes above its capacity,
+// so the transaction is expected to fail the storage capacity check.
+// storageUsed and storageCapacity must be read from the signer's account while nothing is stored
+// at /storage/AStFill.
+var StorageAboveCapacityTransaction = func(
+	storageUsed uint64,
+	storageCapacity uint64,
+	margin uint64,
+) *SimpleTransaction {
+	return must(NewStorageAboveCapacityTransaction(storageUsed, storageCapacity, margin))
+}
+
+func NewStorageAboveCapacityTransaction(
+	storageUsed uint64,
+	storageCapacity uint64,
+	margin uint64,
+) (*SimpleTransaction, error) {
+	if storageUsed > storageCapacity {
+		return nil, invalidParameter("storage used %d exceeds capacity %d", storageUsed, storageCapacity)
+	}
+	tx, err := NewStorageFillTransaction(storageCapacity - storageUsed + margin)
+	if err != nil {
+		return nil, err
+	}
+	return tx.SetExpectedFailure(&ExpectedFailure{
+		ErrorCode: ErrCodeStorageCapacityExceeded,
+	}), nil
+}
+
+// ComputationLimitTransaction runs an empty loop sized to consume about fraction of gasLimit
+// units of computation. Fractions above 1 are expected to fail with a computation limit error.
+// iterationsPerUnit is the number of loop iterations that cost one unit of computation on the
+// target network; calibrate it by dividing the loop length of an EmptyLoopTransaction
+// by its reported computation usage.
+var ComputationLimitTransaction = func(
+	gasLimit uint64,
+	fraction float64,
+	iterationsPerUnit uint64,
+) *SimpleTransaction {
+	return must(NewComputationLimitTransaction(gasLimit, fraction, iterationsPerUnit))
+}
+
+func NewComputationLimitTransaction(
+	gasLimit uint64,
+	fraction float64,
+	iterationsPerUnit uint64,
+) (*SimpleTransaction, error) {
+	err := checkNonZero("gasLimit", gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNonZero("iterationsPerUnit", iterationsPerUnit)
+	if err != nil {
+		return nil, err
+	}
+	err = checkFraction(fraction)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := loopLength(float64(gasLimit) * fraction * float64(iterationsPerUnit))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := EmptyLoopTransaction(length)
+	if fraction > 1 {
+		tx.SetExpectedFailure(&ExpectedFailure{
+			ErrorCode: ErrCodeComputationLimitExceeded,
+		})
+	}
+	return tx, nil
+}
+
+// MemoryLimitTransaction allocates strings and byte arrays and keeps them alive in an array,
+// until about fraction of memoryLimit bytes were allocated.
+// Fractions above 1 are expected to fail with a memory limit error.
+// Each iteration allocates a string of chunkLen bytes and a byte array of chunkLen elements;
+// the loop length assumes an iteration meters at least 2*chunkLen bytes, so the actual usage is
+// somewhat above the requested fraction. The loop also costs computation, so the gas limit
+// of the transaction has to be high enough to reach the memory limit first.
+var MemoryLimitTransaction = func(
+	memoryLimit uint64,
+	fraction float64,
+	chunkLen uint64,
+) *SimpleTransaction {
+	return must(NewMemoryLimitTransaction(memoryLimit, fraction, chunkLen))
+}
+
+func NewMemoryLimitTransaction(
+	memoryLimit uint64,
+	fraction float64,
+	chunkLen uint64,
+) (*SimpleTransaction, error) {
+	err := checkNonZero("memoryLimit", memoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	err = checkChunkLength("chunkLen", chunkLen)
+	if err != nil {
+		return nil, err
+	}
+	err = checkFraction(fraction)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := loopLength(math.Ceil(float64(memoryLimit) * fraction / float64(2*chunkLen)))
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf(`
+				let chunk = "%s"
+				let allocations: [[UInt8]] = []
+				%s
+			`,
+		stringOfLen(chunkLen),
+		LoopTemplate(
+			length,
+			`
+					allocations.append(chunk.concat(i.toString()).utf8)
+				`,
+		),
+	)
+
+	tx := NewSimpleTransaction(
+		body,
+	).SetAuthorizers(Signer())
+	if fraction > 1 {
+		tx.SetExpectedFailure(&ExpectedFailure{
+			ErrorCode: ErrCodeMemoryLimitExceeded,
+		})
+	}
+	return tx, nil
+}
@@ -0,0 +1,39 @@
+package transactions
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLimitTransactionsRejectOverflowingLoopLengths(t *testing.T) {
+	_, err := NewComputationLimitTransaction(math.MaxUint64, 2, math.MaxUint64)
+	if !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("expected invalid parameter error, got %v", err)
+	}
+	_, err = NewMemoryLimitTransaction(math.MaxUint64, 1e10, 1)
+	if !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("expected invalid parameter error, got %v", err)
+	}
+
+	tx, err := NewComputationLimitTransaction(1000, 0.5, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireParses(t, tx)
+}
+
+func TestMemoryLimitTransactionChunkLength(t *testing.T) {
+	for _, chunkLen := range []uint64{0, MaxTransactionByteSize + 1} {
+		_, err := NewMemoryLimitTransaction(1000, 0.5, chunkLen)
+		if !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("expected invalid parameter error for chunk length %d, got %v", chunkLen, err)
+		}
+	}
+
+	tx, err := NewMemoryLimitTransaction(1000, 0.5, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireParses(t, tx)
+}
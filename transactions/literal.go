@@ -0,0 +1,485 @@
+package transactions
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser/lexer"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// CadenceLiteral returns a Cadence expression that evaluates to value,
+// so arbitrary test data can be embedded in transactions.
+//
+// value is either a cadence.Value or a Go value:
+//   - strings, bools, and integers of all sizes (int is Int, uint is UInt, *big.Int is Int)
+//   - flow.Address
+//   - slices and arrays, as variable-sized arrays ([]byte is [UInt8])
+//   - maps, as dictionaries with the entries in the order of their encoded keys
+//   - pointers, as optionals, and nil
+//
+// Go floats are not supported; use cadence.UFix64, cadence.Fix64 etc. for fixed-point numbers.
+//
+// Strings are escaped. Literals whose type would not be inferred correctly, e.g. UInt8 numbers
+// or empty arrays, are cast to their type, e.g. (1 as UInt8), unless the enclosing literal
+// already expects the type, as for the elements of a [UInt8] array or the initializer arguments of a struct.
+// Structs are encoded as a call of the first initializer of the struct type, passing each field
+// to the parameter of the same name, with the parameter's argument label.
+// If the type declares no initializer, the fields are passed as labelled arguments, ordered by name.
+func CadenceLiteral(value any) (string, error) {
+	cadenceValue, staticType, err := cadenceValueOf(reflect.ValueOf(value))
+	if err != nil {
+		return "", err
+	}
+	return encodeLiteral(cadenceValue, staticType, false)
+}
+
+// cadenceValueOf converts a Go value to a Cadence value and its static type.
+// The static type is nil if it is the type of the Cadence value.
+func cadenceValueOf(value reflect.Value) (cadence.Value, cadence.Type, error) {
+	if !value.IsValid() {
+		return cadence.NewOptional(nil), nil, nil
+	}
+
+	switch v := value.Interface().(type) {
+	case cadence.Value:
+		return v, nil, nil
+	case flow.Address:
+		return cadence.NewAddress(v), nil, nil
+	case *big.Int:
+		if v == nil {
+			return nil, nil, fmt.Errorf("nil *big.Int")
+		}
+		return cadence.NewIntFromBig(v), nil, nil
+	case []byte:
+		values := make([]cadence.Value, len(v))
+		for i, b := range v {
+			values[i] = cadence.UInt8(b)
+		}
+		return cadence.NewArray(values).
+			WithType(cadence.NewVariableSizedArrayType(cadence.UInt8Type)), nil, nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return cadence.String(value.String()), nil, nil
+	case reflect.Bool:
+		return cadence.Bool(value.Bool()), nil, nil
+	case reflect.Int:
+		return cadence.NewInt(int(value.Int())), nil, nil
+	case reflect.Int8:
+		return cadence.Int8(value.Int()), nil, nil
+	case reflect.Int16:
+		return cadence.Int16(value.Int()), nil, nil
+	case reflect.Int32:
+		return cadence.Int32(value.Int()), nil, nil
+	case reflect.Int64:
+		return cadence.Int64(value.Int()), nil, nil
+	case reflect.Uint:
+		return cadence.NewUInt(uint(value.Uint())), nil, nil
+	case reflect.Uint8:
+		return cadence.UInt8(value.Uint()), nil, nil
+	case reflect.Uint16:
+		return cadence.UInt16(value.Uint()), nil, nil
+	case reflect.Uint32:
+		return cadence.UInt32(value.Uint()), nil, nil
+	case reflect.Uint64:
+		return cadence.UInt64(value.Uint()), nil, nil
+
+	case reflect.Slice, reflect.Array:
+		elementType, err := cadenceTypeOf(value.Type().Elem())
+		if err != nil {
+			return nil, nil, err
+		}
+		values := make([]cadence.Value, value.Len())
+		for i := range values {
+			values[i], _, err = cadenceValueOf(value.Index(i))
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return cadence.NewArray(values).
+			WithType(cadence.NewVariableSizedArrayType(elementType)), nil, nil
+
+	case reflect.Map:
+		dictionaryType, err := cadenceTypeOf(value.Type())
+		if err != nil {
+			return nil, nil, err
+		}
+		pairs, err := sortedPairs(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cadence.NewDictionary(pairs).
+			WithType(dictionaryType.(*cadence.DictionaryType)), nil, nil
+
+	case reflect.Pointer:
+		optionalType, err := cadenceTypeOf(value.Type())
+		if err != nil {
+			return nil, nil, err
+		}
+		if value.IsNil() {
+			return cadence.NewOptional(nil), optionalType, nil
+		}
+		inner, _, err := cadenceValueOf(value.Elem())
+		if err != nil {
+			return nil, nil, err
+		}
+		return cadence.NewOptional(inner), optionalType, nil
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return cadence.NewOptional(nil), nil, nil
+		}
+		return cadenceValueOf(value.Elem())
+	}
+
+	return nil, nil, fmt.Errorf("unsupported Go type %s", value.Type())
+}
+
+// sortedPairs returns the entries of the map ordered by their encoded keys,
+// as the iteration order of Go maps is random.
+func sortedPairs(value reflect.Value) ([]cadence.KeyValuePair, error) {
+	type entry struct {
+		pair cadence.KeyValuePair
+		key  string
+	}
+	entries := make([]entry, 0, value.Len())
+
+	iterator := value.MapRange()
+	for iterator.Next() {
+		key, _, err := cadenceValueOf(iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+		element, _, err := cadenceValueOf(iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		encodedKey, err := encodeLiteral(key, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{
+			pair: cadence.KeyValuePair{Key: key, Value: element},
+			key:  encodedKey,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	pairs := make([]cadence.KeyValuePair, len(entries))
+	for i, e := range entries {
+		pairs[i] = e.pair
+	}
+	return pairs, nil
+}
+
+var (
+	cadenceValueType = reflect.TypeFor[cadence.Value]()
+	addressType      = reflect.TypeFor[flow.Address]()
+	bigIntType       = reflect.TypeFor[*big.Int]()
+)
+
+// cadenceTypeOf returns the Cadence type of values of the Go type.
+// Interfaces, including cadence.Value, are AnyStruct.
+func cadenceTypeOf(t reflect.Type) (cadence.Type, error) {
+	switch t {
+	case addressType:
+		return cadence.AddressType, nil
+	case bigIntType:
+		return cadence.IntType, nil
+	}
+	if t.Implements(cadenceValueType) && t.Kind() != reflect.Interface {
+		value := reflect.Zero(t).Interface().(cadence.Value)
+		if valueType := value.Type(); valueType != nil {
+			return valueType, nil
+		}
+		return nil, fmt.Errorf("unsupported Go type %s", t)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return cadence.StringType, nil
+	case reflect.Bool:
+		return cadence.BoolType, nil
+	case reflect.Int:
+		return cadence.IntType, nil
+	case reflect.Int8:
+		return cadence.Int8Type, nil
+	case reflect.Int16:
+		return cadence.Int16Type, nil
+	case reflect.Int32:
+		return cadence.Int32Type, nil
+	case reflect.Int64:
+		return cadence.Int64Type, nil
+	case reflect.Uint:
+		return cadence.UIntType, nil
+	case reflect.Uint8:
+		return cadence.UInt8Type, nil
+	case reflect.Uint16:
+		return cadence.UInt16Type, nil
+	case reflect.Uint32:
+		return cadence.UInt32Type, nil
+	case reflect.Uint64:
+		return cadence.UInt64Type, nil
+	case reflect.Interface:
+		return cadence.AnyStructType, nil
+	case reflect.Slice, reflect.Array:
+		elementType, err := cadenceTypeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewVariableSizedArrayType(elementType), nil
+	case reflect.Map:
+		keyType, err := cadenceTypeOf(t.Key())
+		if err != nil {
+			return nil, err
+		}
+		elementType, err := cadenceTypeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewDictionaryType(keyType, elementType), nil
+	case reflect.Pointer:
+		innerType, err := cadenceTypeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptionalType(innerType), nil
+	}
+	return nil, fmt.Errorf("unsupported Go type %s", t)
+}
+
+// encodeLiteral encodes the value. staticType overrides the type of the value, if not nil.
+// If typed is true, the literal is used where its static type is expected,
+// so it is not cast to its type.
+func encodeLiteral(value cadence.Value, staticType cadence.Type, typed bool) (string, error) {
+	if staticType == nil {
+		staticType = value.Type()
+	}
+
+	literal, inferred, err := encodeUntypedLiteral(value, staticType)
+	if err != nil {
+		return "", err
+	}
+	if typed || inferred {
+		return literal, nil
+	}
+	if staticType == nil {
+		return "", fmt.Errorf("type of %s is unknown", value)
+	}
+	return fmt.Sprintf("(%s as %s)", literal, cadenceTypeString(staticType)), nil
+}
+
+// encodeUntypedLiteral encodes the value, and reports whether Cadence infers staticType
+// for the literal without an expected type.
+func encodeUntypedLiteral(value cadence.Value, staticType cadence.Type) (string, bool, error) {
+	switch v := value.(type) {
+	case cadence.String:
+		return ast.QuoteString(string(v)), true, nil
+	case cadence.Character:
+		return ast.QuoteString(string(v)), false, nil
+	case cadence.Bool:
+		return v.String(), true, nil
+	case cadence.Address:
+		return v.String(), true, nil
+	case cadence.Int:
+		return v.String(), true, nil
+	case cadence.UFix64:
+		return v.String(), true, nil
+	case cadence.Int8, cadence.Int16, cadence.Int32, cadence.Int64, cadence.Int128, cadence.Int256,
+		cadence.UInt, cadence.UInt8, cadence.UInt16, cadence.UInt32, cadence.UInt64, cadence.UInt128,
+		cadence.UInt256, cadence.Word8, cadence.Word16, cadence.Word32, cadence.Word64, cadence.Word128,
+		cadence.Word256, cadence.Fix64, cadence.Fix128, cadence.UFix128:
+		return v.String(), false, nil
+
+	case cadence.Path:
+		if !lexer.IsValidIdentifier(v.Identifier) {
+			return "", false, fmt.Errorf("invalid path identifier %q", v.Identifier)
+		}
+		return fmt.Sprintf("/%s/%s", v.Domain.Identifier(), v.Identifier), true, nil
+
+	case cadence.Optional:
+		if v.Value == nil {
+			// nil is inferred as Never?, the type of untyped nil values
+			inferred := staticType == nil || staticType.Equal(cadence.NewOptionalType(cadence.NeverType))
+			return "nil", inferred, nil
+		}
+		var innerType cadence.Type
+		if optionalType, ok := staticType.(*cadence.OptionalType); ok {
+			innerType = optionalType.Type
+		}
+		literal, err := encodeLiteral(v.Value, innerType, true)
+		return literal, false, err
+
+	case cadence.Array:
+		return encodeArray(v, staticType)
+	case cadence.Dictionary:
+		return encodeDictionary(v, staticType)
+	case cadence.Struct:
+		literal, err := encodeStruct(v)
+		return literal, true, err
+	}
+
+	return "", false, fmt.Errorf("unsupported Cadence value %s of type %T", value, value)
+}
+
+func encodeArray(array cadence.Array, staticType cadence.Type) (string, bool, error) {
+	var elementType cadence.Type
+	if arrayType, ok := staticType.(cadence.ArrayType); ok {
+		elementType = arrayType.Element()
+	}
+
+	elements := make([]string, len(array.Values))
+	for i, element := range array.Values {
+		var err error
+		elements[i], err = encodeLiteral(element, nil, isConcrete(elementType))
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	inferred := len(array.Values) > 0 && isInferred(elementType)
+	return "[" + strings.Join(elements, ", ") + "]", inferred, nil
+}
+
+func encodeDictionary(dictionary cadence.Dictionary, staticType cadence.Type) (string, bool, error) {
+	var keyType, elementType cadence.Type
+	if dictionaryType, ok := staticType.(*cadence.DictionaryType); ok {
+		keyType = dictionaryType.KeyType
+		elementType = dictionaryType.ElementType
+	}
+
+	entries := make([]string, len(dictionary.Pairs))
+	for i, pair := range dictionary.Pairs {
+		key, err := encodeLiteral(pair.Key, nil, isConcrete(keyType))
+		if err != nil {
+			return "", false, err
+		}
+		element, err := encodeLiteral(pair.Value, nil, isConcrete(elementType))
+		if err != nil {
+			return "", false, err
+		}
+		entries[i] = key + ": " + element
+	}
+
+	inferred := len(dictionary.Pairs) > 0 && isInferred(keyType) && isInferred(elementType)
+	return "{" + strings.Join(entries, ", ") + "}", inferred, nil
+}
+
+func encodeStruct(value cadence.Struct) (string, error) {
+	if value.StructType == nil {
+		return "", fmt.Errorf("struct %s has no type", value)
+	}
+
+	fields := value.FieldsMappedByName()
+
+	// the initializer parameters are assumed to be named like the fields they initialize,
+	// and are passed with their argument labels
+	type initializerArgument struct {
+		field string
+		label string
+		// parameterType is the type of the parameter, if known
+		parameterType cadence.Type
+	}
+	var initializerArguments []initializerArgument
+	if len(value.StructType.Initializers) > 0 {
+		for _, parameter := range value.StructType.Initializers[0] {
+			label := parameter.Label
+			if label == "" {
+				label = parameter.Identifier
+			}
+			initializerArguments = append(initializerArguments, initializerArgument{
+				field:         parameter.Identifier,
+				label:         label,
+				parameterType: parameter.Type,
+			})
+		}
+	} else {
+		fieldTypes := value.StructType.FieldsMappedByName()
+		for name := range fields {
+			initializerArguments = append(initializerArguments, initializerArgument{
+				field:         name,
+				label:         name,
+				parameterType: fieldTypes[name],
+			})
+		}
+		sort.Slice(initializerArguments, func(i, j int) bool {
+			return initializerArguments[i].field < initializerArguments[j].field
+		})
+	}
+
+	arguments := make([]string, 0, len(initializerArguments))
+	for _, initializerArgument := range initializerArguments {
+		field, ok := fields[initializerArgument.field]
+		if !ok {
+			return "", fmt.Errorf(
+				"struct %s has no field %s",
+				value.StructType.QualifiedIdentifier,
+				initializerArgument.field,
+			)
+		}
+		argument, err := encodeLiteral(field, nil, isConcrete(initializerArgument.parameterType))
+		if err != nil {
+			return "", err
+		}
+		if initializerArgument.label != "_" {
+			argument = initializerArgument.label + ": " + argument
+		}
+		arguments = append(arguments, argument)
+	}
+	return fmt.Sprintf("%s(%s)", value.StructType.QualifiedIdentifier, strings.Join(arguments, ", ")), nil
+}
+
+// isConcrete reports whether the literals of values of the type can omit their type,
+// as the type is expected. Abstract types, e.g. AnyStruct or Number, do not determine the type of literals.
+func isConcrete(t cadence.Type) bool {
+	switch t := t.(type) {
+	case nil, *cadence.IntersectionType:
+		return false
+	case *cadence.OptionalType:
+		return isConcrete(t.Type)
+	}
+	switch t {
+	case cadence.AnyStructType, cadence.AnyType, cadence.HashableStructType,
+		cadence.NumberType, cadence.SignedNumberType,
+		cadence.IntegerType, cadence.SignedIntegerType, cadence.FixedSizeUnsignedIntegerType,
+		cadence.FixedPointType, cadence.SignedFixedPointType,
+		cadence.PathType, cadence.CapabilityPathType:
+		return false
+	}
+	return true
+}
+
+// isInferred reports whether Cadence infers the type for literals without an expected type.
+func isInferred(t cadence.Type) bool {
+	switch t {
+	case cadence.StringType, cadence.BoolType, cadence.IntType, cadence.UFix64Type, cadence.AddressType:
+		return true
+	}
+	return false
+}
+
+// cadenceTypeString returns the type in Cadence syntax.
+func cadenceTypeString(t cadence.Type) string {
+	switch t := t.(type) {
+	case *cadence.OptionalType:
+		return cadenceTypeString(t.Type) + "?"
+	case *cadence.VariableSizedArrayType:
+		return "[" + cadenceTypeString(t.ElementType) + "]"
+	case *cadence.ConstantSizedArrayType:
+		return fmt.Sprintf("[%s; %d]", cadenceTypeString(t.ElementType), t.Size)
+	case *cadence.DictionaryType:
+		return fmt.Sprintf("{%s: %s}", cadenceTypeString(t.KeyType), cadenceTypeString(t.ElementType))
+	case cadence.CompositeType:
+		return t.CompositeTypeQualifiedIdentifier()
+	}
+	return t.ID()
+}
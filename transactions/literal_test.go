@@ -0,0 +1,86 @@
+package transactions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-standard-transactions/datagen"
+)
+
+// TestCadenceLiteral checks the encoded literals, and that they evaluate to values of the expected run-time type.
+func TestCadenceLiteral(t *testing.T) {
+	negative := int8(-5)
+
+	tests := []struct {
+		value    any
+		literal  string
+		typeName string
+	}{
+		{
+			value:    "a \"quoted\"\n\\ string",
+			literal:  `"a \"quoted\"\n\\ string"`,
+			typeName: "String",
+		},
+		{value: -42, literal: `-42`, typeName: "Int"},
+		{value: int8(-8), literal: `(-8 as Int8)`, typeName: "Int8"},
+		{value: cadence.Fix64(-150000000), literal: `(-1.50000000 as Fix64)`, typeName: "Fix64"},
+		{value: []int8{-1, 2}, literal: `([-1, 2] as [Int8])`, typeName: "[Int8]"},
+		{value: []byte{}, literal: `([] as [UInt8])`, typeName: "[UInt8]"},
+		{value: &negative, literal: `(-5 as Int8?)`, typeName: "Int8?"},
+		// nil has the run-time type Never? whatever its static type
+		{value: (*int8)(nil), literal: `(nil as Int8?)`, typeName: "Never?"},
+		{value: []*int8{&negative, nil}, literal: `([-5, nil] as [Int8?])`, typeName: "[Int8?]"},
+		{value: map[string]*int{"a": nil}, literal: `({"a": nil} as {String: Int?})`, typeName: "{String: Int?}"},
+		{
+			value:    cadence.NewOptional(cadence.NewOptional(cadence.UInt8(1))),
+			literal:  `(1 as UInt8??)`,
+			typeName: "UInt8??",
+		},
+		{value: []any{uint8(1), "s"}, literal: `([(1 as UInt8), "s"] as [AnyStruct])`, typeName: "[AnyStruct]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.literal, func(t *testing.T) {
+			literal, err := CadenceLiteral(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if literal != test.literal {
+				t.Fatalf("encoded %#v as %s, expected %s", test.value, literal, test.literal)
+			}
+
+			err = executeTransaction(NewSimpleTransaction(fmt.Sprintf(
+				`
+					let value = %s
+					assert(value.getType() == Type<%s>(), message: value.getType().identifier)
+				`,
+				literal,
+				test.typeName,
+			)).SetAuthorizers(Signer()))
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestCadenceLiteralStruct checks that struct initializer arguments of concrete parameter types are not cast,
+// and that arguments of abstract parameter types are.
+func TestCadenceLiteralStruct(t *testing.T) {
+	node := cadence.NewStruct([]cadence.Value{
+		cadence.String("root"),
+		cadence.NewArray([]cadence.Value{cadence.UInt8(1)}).
+			WithType(cadence.NewVariableSizedArrayType(cadence.AnyStructType)),
+	}).WithType(datagen.NodeType(nil))
+
+	literal, err := CadenceLiteral(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `TestContract.Node(label: "root", children: [(1 as UInt8)])`
+	if literal != expected {
+		t.Errorf("encoded struct as %s, expected %s", literal, expected)
+	}
+}
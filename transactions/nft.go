@@ -0,0 +1,145 @@
+package transactions
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// NFT TRANSACTIONS
+
+// nftContract is the TestNFT contract, a NonFungibleToken with MetadataViews and an unrestricted minter.
+// The NFT transactions import it, and the standard contracts it imports.
+//
+//go:embed nft_contract.cdc
+var nftContract []byte
+
+// SetupNFTCollectionTransaction saves an empty TestNFT collection to the signer's storage
+// and publishes a capability to it, unless the signer already has one.
+var SetupNFTCollectionTransaction = NewSimpleTransaction(
+	`
+		if signer.storage.type(at: TestNFT.CollectionStoragePath) == nil {
+			let collection <- TestNFT.createEmptyCollection(nftType: Type<@TestNFT.NFT>())
+			signer.storage.save(<- collection, to: TestNFT.CollectionStoragePath)
+
+			let cap = signer.capabilities.storage.issue<&TestNFT.Collection>(TestNFT.CollectionStoragePath)
+			signer.capabilities.publish(cap, at: TestNFT.CollectionPublicPath)
+		}
+	`,
+).SetAuthorizers(Signer(
+	EntitlementSaveValue,
+	EntitlementIssueStorageCapabilityController,
+	EntitlementPublishCapability,
+))
+
+// MintNFTToCollectionTransaction mints loopLength NFTs into the signer's collection.
+var MintNFTToCollectionTransaction = func(loopLength uint64) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				let collection = signer.capabilities.borrow<&TestNFT.Collection>(TestNFT.CollectionPublicPath)
+					?? panic("signer has no TestNFT collection")
+				%s
+			`,
+			LoopTemplate(loopLength, `collection.deposit(token: <- TestNFT.mintNFT())`),
+		),
+	).SetAuthorizers(Signer()).
+		SetExpectedEvents(ExpectedEvent{Type: EventNFTDeposited, Count: loopLength})
+}
+
+// TransferNFTsTransaction transfers numNFTs NFTs from the signer's collection
+// to the collection of recipient.
+var TransferNFTsTransaction = func(recipient flow.Address, numNFTs uint64) *SimpleTransaction {
+	return must(NewTransferNFTsTransaction(recipient, numNFTs))
+}
+
+func NewTransferNFTsTransaction(recipient flow.Address, numNFTs uint64) (*SimpleTransaction, error) {
+	err := checkNonZero("numNFTs", numNFTs)
+	if err != nil {
+		return nil, err
+	}
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				let collection = signer.storage.borrow<auth(NonFungibleToken.Withdraw) &TestNFT.Collection>(
+					from: TestNFT.CollectionStoragePath
+				) ?? panic("signer has no TestNFT collection")
+				let receiver = getAccount(%s).capabilities.borrow<&{NonFungibleToken.Receiver}>(
+					TestNFT.CollectionPublicPath
+				) ?? panic("recipient has no TestNFT collection")
+				let ids = collection.getIDs()
+				assert(ids.length >= %d, message: "signer has fewer than %d NFTs")
+				%s
+			`,
+			recipient.HexWithPrefix(),
+			numNFTs,
+			numNFTs,
+			LoopTemplate(
+				numNFTs,
+				`receiver.deposit(token: <- collection.withdraw(withdrawID: ids[i - 1]))`,
+			),
+		),
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(
+			ExpectedEvent{Type: EventNFTWithdrawn, Count: numNFTs},
+			ExpectedEvent{Type: EventNFTDeposited, Count: numNFTs},
+		), nil
+}
+
+// BurnNFTsTransaction withdraws numNFTs NFTs from the signer's collection and destroys them.
+var BurnNFTsTransaction = func(numNFTs uint64) *SimpleTransaction {
+	return must(NewBurnNFTsTransaction(numNFTs))
+}
+
+func NewBurnNFTsTransaction(numNFTs uint64) (*SimpleTransaction, error) {
+	err := checkNonZero("numNFTs", numNFTs)
+	if err != nil {
+		return nil, err
+	}
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				let collection = signer.storage.borrow<auth(NonFungibleToken.Withdraw) &TestNFT.Collection>(
+					from: TestNFT.CollectionStoragePath
+				) ?? panic("signer has no TestNFT collection")
+				let ids = collection.getIDs()
+				assert(ids.length >= %d, message: "signer has fewer than %d NFTs")
+				%s
+			`,
+			numNFTs,
+			numNFTs,
+			LoopTemplate(
+				numNFTs,
+				`destroy collection.withdraw(withdrawID: ids[i - 1])`,
+			),
+		),
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(
+			ExpectedEvent{Type: EventNFTWithdrawn, Count: numNFTs},
+			ExpectedEvent{Type: EventNFTDestroyed, Count: numNFTs},
+		), nil
+}
+
+// ResolveNFTViewsTransaction resolves all views of the NFTs in the signer's collection
+// with MetadataViews.getNFTView, loopLength times in total.
+// The collection must not be empty.
+var ResolveNFTViewsTransaction = func(loopLength uint64) *SimpleTransaction {
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				let collection = signer.capabilities.borrow<&TestNFT.Collection>(TestNFT.CollectionPublicPath)
+					?? panic("signer has no TestNFT collection")
+				let ids = collection.getIDs()
+				%s
+			`,
+			LoopTemplate(
+				loopLength,
+				`
+					let id = ids[(i - 1) % ids.length]
+					let view = MetadataViews.getNFTView(id: id, viewResolver: collection.borrowViewResolver(id: id)!)
+				`,
+			),
+		),
+	).SetAuthorizers(Signer())
+}
@@ -0,0 +1,273 @@
+package transactions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// ParameterType is the type of a template parameter.
+type ParameterType string
+
+const (
+	ParameterTypeUint64  ParameterType = "uint64"
+	ParameterTypeInt64   ParameterType = "int64"
+	ParameterTypeFloat64 ParameterType = "float64"
+	ParameterTypeString  ParameterType = "string"
+	ParameterTypeBool    ParameterType = "bool"
+	ParameterTypeStrings ParameterType = "[]string"
+)
+
+// Parameter describes a parameter of a template.
+// Min and Max bound numeric parameters, and the length of string and string list parameters.
+// Enum restricts string parameters to the listed values.
+// A parameter without a default is required.
+type Parameter struct {
+	Name        string        `json:"name" yaml:"name"`
+	Type        ParameterType `json:"type" yaml:"type"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Default     any           `json:"default,omitempty" yaml:"default,omitempty"`
+	Min         *float64      `json:"min,omitempty" yaml:"min,omitempty"`
+	Max         *float64      `json:"max,omitempty" yaml:"max,omitempty"`
+	Enum        []string      `json:"enum,omitempty" yaml:"enum,omitempty"`
+}
+
+// Parameters is the parameter schema of a template.
+type Parameters []Parameter
+
+// Validate checks that the parameters have unique names, known types,
+// and defaults that are valid values.
+func (ps Parameters) Validate() error {
+	names := map[string]bool{}
+	for _, p := range ps {
+		if p.Name == "" {
+			return fmt.Errorf("parameter without name")
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate parameter %s", p.Name)
+		}
+		names[p.Name] = true
+
+		if p.Min != nil && p.Max != nil && *p.Min > *p.Max {
+			return fmt.Errorf("parameter %s: min %v exceeds max %v", p.Name, *p.Min, *p.Max)
+		}
+		if p.Default == nil {
+			if !slices.Contains(parameterTypes, p.Type) {
+				return fmt.Errorf("parameter %s: unknown type %q", p.Name, p.Type)
+			}
+			continue
+		}
+		_, err := p.value(p.Default)
+		if err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+	return nil
+}
+
+// Resolve converts the values to the types of the parameters, fills in defaults
+// and checks the bounds. It returns an error for missing required parameters
+// and for values of unknown parameters.
+func (ps Parameters) Resolve(values map[string]any) (map[string]any, error) {
+	for name := range values {
+		if !slices.ContainsFunc(ps, func(p Parameter) bool { return p.Name == name }) {
+			return nil, fmt.Errorf("unknown parameter %s", name)
+		}
+	}
+
+	resolved := make(map[string]any, len(ps))
+	for _, p := range ps {
+		raw, ok := values[p.Name]
+		if !ok || raw == nil {
+			raw = p.Default
+		}
+		if raw == nil {
+			return nil, fmt.Errorf("missing required parameter %s", p.Name)
+		}
+
+		value, err := p.value(raw)
+		if err != nil {
+			return nil, err
+		}
+		resolved[p.Name] = value
+	}
+	return resolved, nil
+}
+
+var parameterTypes = []ParameterType{
+	ParameterTypeUint64,
+	ParameterTypeInt64,
+	ParameterTypeFloat64,
+	ParameterTypeString,
+	ParameterTypeBool,
+	ParameterTypeStrings,
+}
+
+// value converts raw to the type of the parameter and checks the bounds.
+func (p Parameter) value(raw any) (any, error) {
+	var value any
+	var size float64
+	var err error
+
+	switch p.Type {
+	case ParameterTypeUint64:
+		var v uint64
+		v, err = toUint64(raw)
+		value, size = v, float64(v)
+	case ParameterTypeInt64:
+		var v int64
+		v, err = toInt64(raw)
+		value, size = v, float64(v)
+	case ParameterTypeFloat64:
+		var v float64
+		v, err = toFloat64(raw)
+		value, size = v, v
+	case ParameterTypeString:
+		v, ok := raw.(string)
+		if !ok {
+			err = fmt.Errorf("expected string, got %T", raw)
+		} else if len(p.Enum) > 0 && !slices.Contains(p.Enum, v) {
+			err = fmt.Errorf("%q is not one of %v", v, p.Enum)
+		}
+		value, size = v, float64(len(v))
+	case ParameterTypeBool:
+		var v bool
+		v, err = toBool(raw)
+		value = v
+	case ParameterTypeStrings:
+		var v []string
+		v, err = toStrings(raw)
+		value, size = v, float64(len(v))
+	default:
+		err = fmt.Errorf("unknown type %q", p.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+	}
+
+	if p.Type == ParameterTypeBool {
+		return value, nil
+	}
+	if p.Min != nil && size < *p.Min {
+		return nil, fmt.Errorf("parameter %s: %v is below the minimum %v", p.Name, raw, *p.Min)
+	}
+	if p.Max != nil && size > *p.Max {
+		return nil, fmt.Errorf("parameter %s: %v is above the maximum %v", p.Name, raw, *p.Max)
+	}
+	return value, nil
+}
+
+func toUint64(raw any) (uint64, error) {
+	switch v := raw.(type) {
+	case uint64:
+		return v, nil
+	case uint:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case int, int32, int64, float64, json.Number, string:
+		i, err := toInt64(v)
+		if err != nil {
+			return toUint64Fallback(raw, err)
+		}
+		if i < 0 {
+			return 0, fmt.Errorf("expected unsigned integer, got %v", raw)
+		}
+		return uint64(i), nil
+	default:
+		return 0, fmt.Errorf("expected unsigned integer, got %T", raw)
+	}
+}
+
+// toUint64Fallback parses values above the int64 range.
+func toUint64Fallback(raw any, err error) (uint64, error) {
+	switch v := raw.(type) {
+	case json.Number:
+		return strconv.ParseUint(string(v), 10, 64)
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	}
+	return 0, err
+}
+
+func toInt64(raw any) (int64, error) {
+	switch v := raw.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("%d is out of range", v)
+		}
+		return int64(v), nil
+	case uint:
+		return toInt64(uint64(v))
+	case uint32:
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return 0, fmt.Errorf("expected integer, got %v", v)
+		}
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("expected integer, got %T", raw)
+	}
+}
+
+func toFloat64(raw any) (float64, error) {
+	switch v := raw.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		i, err := toInt64(raw)
+		if err != nil {
+			return 0, fmt.Errorf("expected number, got %T", raw)
+		}
+		return float64(i), nil
+	}
+}
+
+func toBool(raw any) (bool, error) {
+	switch v := raw.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	default:
+		return false, fmt.Errorf("expected bool, got %T", raw)
+	}
+}
+
+func toStrings(raw any) ([]string, error) {
+	switch v := raw.(type) {
+	case []string:
+		return v, nil
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, element := range v {
+			str, ok := element.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected list of strings, got element of type %T", element)
+			}
+			strs = append(strs, str)
+		}
+		return strs, nil
+	default:
+		return nil, fmt.Errorf("expected list of strings, got %T", raw)
+	}
+}
@@ -0,0 +1,82 @@
+package transactions
+
+import (
+	"fmt"
+)
+
+// MapRegistry is a Registry of transactions and templates registered under unique labels.
+// Get constructs the transactions of templates with the default parameter values,
+// and returns an error for templates with required parameters.
+type MapRegistry struct {
+	transactions map[Label]Transaction
+	templates    map[Label]Template
+	labels       []Label
+}
+
+var _ Registry = (*MapRegistry)(nil)
+
+func NewRegistry() *MapRegistry {
+	return &MapRegistry{
+		transactions: map[Label]Transaction{},
+		templates:    map[Label]Template{},
+	}
+}
+
+// Register adds the transaction under label.
+// It returns an error if the label is already registered.
+func (r *MapRegistry) Register(label Label, tx Transaction) error {
+	if r.registered(label) {
+		return fmt.Errorf("transaction %q is already registered", label)
+	}
+	r.transactions[label] = tx
+	r.labels = append(r.labels, label)
+	return nil
+}
+
+// RegisterTemplate adds the template under label.
+// It returns an error if the label is already registered.
+func (r *MapRegistry) RegisterTemplate(label Label, template Template) error {
+	if r.registered(label) {
+		return fmt.Errorf("transaction %q is already registered", label)
+	}
+	r.templates[label] = template
+	r.labels = append(r.labels, label)
+	return nil
+}
+
+func (r *MapRegistry) registered(label Label) bool {
+	_, isTransaction := r.transactions[label]
+	_, isTemplate := r.templates[label]
+	return isTransaction || isTemplate
+}
+
+func (r *MapRegistry) Get(label Label) (Transaction, error) {
+	if tx, ok := r.transactions[label]; ok {
+		return tx, nil
+	}
+	template, ok := r.templates[label]
+	if !ok {
+		return nil, fmt.Errorf("transaction %q is not registered", label)
+	}
+	tx, err := template.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct %s with default parameters: %w", label, err)
+	}
+	return tx, nil
+}
+
+// GetTemplate returns the template registered under label.
+func (r *MapRegistry) GetTemplate(label Label) (Template, error) {
+	template, ok := r.templates[label]
+	if !ok {
+		return nil, fmt.Errorf("template %q is not registered", label)
+	}
+	return template, nil
+}
+
+// AllLabels returns the labels in registration order.
+func (r *MapRegistry) AllLabels() []Label {
+	labels := make([]Label, len(r.labels))
+	copy(labels, r.labels)
+	return labels
+}
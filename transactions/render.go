@@ -0,0 +1,106 @@
+package transactions
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Imports maps contract names to the address they are imported from.
+// Contracts mapped to the empty address (e.g. Crypto) are imported by name only.
+type Imports map[string]flow.Address
+
+// Render returns the Cadence source code of the transaction.
+// The imports of the transaction are added to the imports, which take precedence.
+// Only the contracts of imports that are referenced by the transaction are imported.
+func Render(tx Transaction, imports Imports) string {
+	if txImports := tx.GetImports(); len(txImports) > 0 {
+		merged := maps.Clone(txImports)
+		maps.Copy(merged, imports)
+		imports = merged
+	}
+
+	fieldDeclarations := tx.GetFieldDeclarations()
+	prepareBlock := tx.GetPrepareBlock()
+	preConditions := tx.GetPreConditions()
+	executeBlock := tx.GetExecuteBlock()
+	postConditions := tx.GetPostConditions()
+
+	builder := strings.Builder{}
+	builder.WriteString(renderImports(
+		imports,
+		fieldDeclarations,
+		prepareBlock,
+		preConditions,
+		executeBlock,
+		postConditions,
+	))
+
+	builder.WriteString("transaction {\n")
+	if strings.TrimSpace(fieldDeclarations) != "" {
+		builder.WriteString(TrimAndReplaceIndentation(fieldDeclarations, 4))
+		builder.WriteRune('\n')
+	}
+
+	authorizers := tx.GetAuthorizers()
+	if authorizers == nil {
+		authorizers = DefaultAuthorizers
+	}
+	parameters := make([]string, 0, len(authorizers))
+	for _, authorizer := range authorizers {
+		parameters = append(parameters, authorizer.Parameter())
+	}
+
+	builder.WriteString(fmt.Sprintf("    prepare(%s) {\n", strings.Join(parameters, ", ")))
+	if strings.TrimSpace(prepareBlock) != "" {
+		builder.WriteString(TrimAndReplaceIndentation(prepareBlock, 8))
+	}
+	builder.WriteString("    }\n")
+
+	writeBlock(&builder, "pre", preConditions)
+	writeBlock(&builder, "execute", executeBlock)
+	writeBlock(&builder, "post", postConditions)
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+func writeBlock(builder *strings.Builder, keyword string, block string) {
+	if strings.TrimSpace(block) == "" {
+		return
+	}
+	builder.WriteString(fmt.Sprintf("\n    %s {\n", keyword))
+	builder.WriteString(TrimAndReplaceIndentation(block, 8))
+	builder.WriteString("    }\n")
+}
+
+func renderImports(imports Imports, code ...string) string {
+	source := strings.Join(code, "\n")
+
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(source) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	builder := strings.Builder{}
+	for _, name := range names {
+		address := imports[name]
+		if address == flow.EmptyAddress {
+			builder.WriteString(fmt.Sprintf("import %s\n", name))
+		} else {
+			builder.WriteString(fmt.Sprintf("import %s from %s\n", name, address.HexWithPrefix()))
+		}
+	}
+	builder.WriteRune('\n')
+	return builder.String()
+}
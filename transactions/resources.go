@@ -0,0 +1,346 @@
+package transactions
+
+import (
+	"fmt"
+	"math"
+)
+
+// RESOURCE TRANSACTIONS
+
+// ResourceCollection is the kind of nested resource collections created by TestContract.
+// The leaves of the collections are TestContract.Item resources,
+// which emit a default destroy event when they are destroyed.
+type ResourceCollection string
+
+const (
+	ResourceArray      ResourceCollection = "array"
+	ResourceDictionary ResourceCollection = "dictionary"
+)
+
+// ResourceCollections lists all resource collection kinds.
+var ResourceCollections = []ResourceCollection{
+	ResourceArray,
+	ResourceDictionary,
+}
+
+// resourceCollectionStoragePath is where SaveResourceCollectionTransaction stores collections.
+const resourceCollectionStoragePath = "/storage/AStResources"
+
+// create returns the expression creating a collection of depth levels with size elements each.
+func (c ResourceCollection) create(size uint64, depth uint64) string {
+	switch c {
+	case ResourceArray:
+		return fmt.Sprintf("TestContract.createItemArray(size: %d, depth: %d)", size, depth)
+	default:
+		return fmt.Sprintf("TestContract.createItemDictionary(size: %d, depth: %d)", size, depth)
+	}
+}
+
+// empty returns the expression of an empty collection.
+func (c ResourceCollection) empty() string {
+	switch c {
+	case ResourceArray:
+		return "<- []"
+	default:
+		return "<- {}"
+	}
+}
+
+// staticType returns the type of the top level of the collection.
+func (c ResourceCollection) staticType() string {
+	switch c {
+	case ResourceArray:
+		return "@[AnyResource]"
+	default:
+		return "@{String: AnyResource}"
+	}
+}
+
+// key returns the expression of the key of the element at index, an Int expression.
+func (c ResourceCollection) key(index string) string {
+	switch c {
+	case ResourceArray:
+		return index
+	default:
+		return fmt.Sprintf("(%s).toString()", index)
+	}
+}
+
+// resourceCollectionItems checks the collection parameters
+// and returns the number of items at the leaves, size^depth.
+func resourceCollectionItems(collection ResourceCollection, size uint64, depth uint64) (uint64, error) {
+	switch collection {
+	case ResourceArray, ResourceDictionary:
+	default:
+		return 0, invalidParameter("unknown resource collection %q", collection)
+	}
+	err := checkNonZero("size", size)
+	if err != nil {
+		return 0, err
+	}
+	err = checkNonZero("depth", depth)
+	if err != nil {
+		return 0, err
+	}
+
+	items := uint64(1)
+	for range depth {
+		if items > math.MaxInt64/size {
+			return 0, invalidParameter("%d levels of %d elements overflow", depth, size)
+		}
+		items *= size
+	}
+	return items, nil
+}
+
+// CreateAndDestroyResourceCollectionTransaction creates a nested resource collection
+// of depth levels with size elements each, and destroys it, loopLength times.
+var CreateAndDestroyResourceCollectionTransaction = func(
+	loopLength uint64,
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) *SimpleTransaction {
+	return must(NewCreateAndDestroyResourceCollectionTransaction(loopLength, collection, size, depth))
+}
+
+func NewCreateAndDestroyResourceCollectionTransaction(
+	loopLength uint64,
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) (*SimpleTransaction, error) {
+	items, err := resourceCollectionItems(collection, size, depth)
+	if err != nil {
+		return nil, err
+	}
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				let collection <- %s
+				destroy collection
+			`,
+			collection.create(size, depth),
+		),
+	).SetAuthorizers(Signer()).
+		SetExpectedEvents(ExpectedEvent{Type: EventItemDestroyed, Count: loopLength * items}), nil
+}
+
+// MoveResourceCollectionTransaction creates a nested resource collection
+// of depth levels with size elements each, and loopLength times moves its top-level elements
+// one by one into a second collection, and swaps the two collections with <->.
+var MoveResourceCollectionTransaction = func(
+	loopLength uint64,
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) *SimpleTransaction {
+	return must(NewMoveResourceCollectionTransaction(loopLength, collection, size, depth))
+}
+
+func NewMoveResourceCollectionTransaction(
+	loopLength uint64,
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) (*SimpleTransaction, error) {
+	items, err := resourceCollectionItems(collection, size, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	var move string
+	switch collection {
+	case ResourceArray:
+		move = `
+			while source.length > 0 {
+				target.append(<- source.removeLast())
+			}
+		`
+	default:
+		move = `
+			for key in source.keys {
+				target[key] <-! source.remove(key: key)!
+			}
+		`
+	}
+
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				var source: %[1]s <- %[2]s
+				var target: %[1]s %[3]s
+				%[4]s
+				destroy source
+				destroy target
+			`,
+			collection.staticType(),
+			collection.create(size, depth),
+			collection.empty(),
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(
+					`
+						%s
+						source <-> target
+					`,
+					move,
+				),
+			),
+		),
+	).SetAuthorizers(Signer()).
+		SetExpectedEvents(ExpectedEvent{Type: EventItemDestroyed, Count: items}), nil
+}
+
+// SwapResourceCollectionElementsTransaction creates a nested resource collection
+// of depth levels with size elements each, and swaps neighbouring top-level elements
+// with <-> loopLength times.
+var SwapResourceCollectionElementsTransaction = func(
+	loopLength uint64,
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) *SimpleTransaction {
+	return must(NewSwapResourceCollectionElementsTransaction(loopLength, collection, size, depth))
+}
+
+func NewSwapResourceCollectionElementsTransaction(
+	loopLength uint64,
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) (*SimpleTransaction, error) {
+	items, err := resourceCollectionItems(collection, size, depth)
+	if err != nil {
+		return nil, err
+	}
+	if size < 2 {
+		return nil, invalidParameter("size must be at least 2 to swap elements, got %d", size)
+	}
+
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				let collection <- %s
+				%s
+				destroy collection
+			`,
+			collection.create(size, depth),
+			LoopTemplate(
+				loopLength,
+				fmt.Sprintf(
+					`collection[%s] <-> collection[%s]`,
+					collection.key(fmt.Sprintf("(i - 1) %% %d", size)),
+					collection.key(fmt.Sprintf("i %% %d", size)),
+				),
+			),
+		),
+	).SetAuthorizers(Signer()).
+		SetExpectedEvents(ExpectedEvent{Type: EventItemDestroyed, Count: items}), nil
+}
+
+// SaveResourceCollectionTransaction replaces the resource collection stored at /storage/AStResources
+// with a new nested collection of depth levels with size elements each.
+// The replaced collection is destroyed.
+var SaveResourceCollectionTransaction = func(
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) *SimpleTransaction {
+	return must(NewSaveResourceCollectionTransaction(collection, size, depth))
+}
+
+func NewSaveResourceCollectionTransaction(
+	collection ResourceCollection,
+	size uint64,
+	depth uint64,
+) (*SimpleTransaction, error) {
+	_, err := resourceCollectionItems(collection, size, depth)
+	if err != nil {
+		return nil, err
+	}
+	return NewSimpleTransaction(
+		fmt.Sprintf(
+			`
+				destroy signer.storage.load<@AnyResource>(from: %[1]s)
+				signer.storage.save(<- %[2]s, to: %[1]s)
+			`,
+			resourceCollectionStoragePath,
+			collection.create(size, depth),
+		),
+	).SetAuthorizers(Signer(EntitlementLoadValue, EntitlementSaveValue)), nil
+}
+
+// LoadAndSaveResourceCollectionTransaction loads the resource collection
+// stored by SaveResourceCollectionTransaction and saves it back, loopLength times.
+var LoadAndSaveResourceCollectionTransaction = func(loopLength uint64) *SimpleTransaction {
+	return simpleTransactionWithLoop(
+		loopLength,
+		fmt.Sprintf(
+			`
+				let collection <- signer.storage.load<@AnyResource>(from: %[1]s)!
+				signer.storage.save(<- collection, to: %[1]s)
+			`,
+			resourceCollectionStoragePath,
+		),
+	).SetAuthorizers(Signer(EntitlementLoadValue, EntitlementSaveValue))
+}
+
+// LoadAndDestroyResourceCollectionTransaction destroys the resource collection
+// stored by SaveResourceCollectionTransaction, if any.
+var LoadAndDestroyResourceCollectionTransaction = NewSimpleTransaction(
+	fmt.Sprintf(
+		`destroy signer.storage.load<@AnyResource>(from: %s)`,
+		resourceCollectionStoragePath,
+	),
+).SetAuthorizers(Signer(EntitlementLoadValue))
+
+var resourceCollectionParameters = Parameters{
+	{
+		Name:        "collection",
+		Type:        ParameterTypeString,
+		Description: "Kind of the resource collection at all levels.",
+		Default:     string(ResourceDictionary),
+		Enum:        []string{string(ResourceArray), string(ResourceDictionary)},
+	},
+	{
+		Name:        "size",
+		Type:        ParameterTypeUint64,
+		Description: "Number of elements of each collection.",
+		Default:     uint64(10),
+		Min:         bound(1),
+	},
+	{
+		Name:        "depth",
+		Type:        ParameterTypeUint64,
+		Description: "Number of nested collection levels above the items.",
+		Default:     uint64(1),
+		Min:         bound(1),
+	},
+}
+
+func resourceCollectionTemplate(
+	label Label,
+	constructor func(
+		loopLength uint64,
+		collection ResourceCollection,
+		size uint64,
+		depth uint64,
+	) (*SimpleTransaction, error),
+) NamedTemplate {
+	return NamedTemplate{
+		Label: label,
+		Template: &FuncTemplate{
+			Schema: append(Parameters{loopLengthParameter}, resourceCollectionParameters...),
+			Construct: func(values Values) (*SimpleTransaction, error) {
+				return constructor(
+					values.Uint64("loopLength"),
+					ResourceCollection(values.String("collection")),
+					values.Uint64("size"),
+					values.Uint64("depth"),
+				)
+			},
+		},
+	}
+}
@@ -33,6 +33,13 @@ const scheduleTemplate = `
 	destroy scheduledTransaction
 `
 
+var scheduleAuthorizer = Signer(
+	EntitlementBorrowValue,
+	EntitlementSaveValue,
+	EntitlementGetStorageCapabilityController,
+	EntitlementIssueStorageCapabilityController,
+)
+
 var ScheduledTransactionAndExecuteTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
@@ -43,10 +50,25 @@ var ScheduledTransactionAndExecuteTransaction = func(loopLength uint64) *SimpleT
 				let priority = FlowTransactionScheduler.Priority.High
 				let data: UInt64 = 0
 			`),
-	)
+	).SetAuthorizers(scheduleAuthorizer)
 }
 
 var ScheduledTransactionAndExecuteWithLargeDataTransaction = func(loopLength uint64, dataSize uint64) *SimpleTransaction {
+	return scheduledTransactionAndExecuteWithLargeDataTransaction(loopLength, dataSize)
+}
+
+func NewScheduledTransactionAndExecuteWithLargeDataTransaction(
+	loopLength uint64,
+	dataSize uint64,
+) (*SimpleTransaction, error) {
+	err := checkLength("dataSize", dataSize, 100)
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(scheduledTransactionAndExecuteWithLargeDataTransaction(loopLength, dataSize))
+}
+
+func scheduledTransactionAndExecuteWithLargeDataTransaction(loopLength uint64, dataSize uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		fmt.Sprintf(scheduleTemplate, fmt.Sprintf(`
@@ -56,7 +78,7 @@ var ScheduledTransactionAndExecuteWithLargeDataTransaction = func(loopLength uin
 			let priority = FlowTransactionScheduler.Priority.High
 			let data = "%s"
 		`, strings.Repeat("A", int(100*dataSize)))), // inject dataSize KB of data
-	)
+	).SetAuthorizers(scheduleAuthorizer)
 }
 
 var ScheduledTransactionAndExecuteWithLargeArrayTransaction = func(loopLength uint64, arraySize uint64) *SimpleTransaction {
@@ -66,6 +88,7 @@ var ScheduledTransactionAndExecuteWithLargeArrayTransaction = func(loopLength ui
 			let largeArray: [Int] = []
 			while largeArray.length < %d {
 				largeArray.append(1)
+			}
 
 			let fees <- vault.withdraw(amount: 0.01) as! @FlowToken.Vault
 			let timestamp = getCurrentBlock().timestamp + 120.0 // 2 minutes in future
@@ -73,5 +96,5 @@ var ScheduledTransactionAndExecuteWithLargeArrayTransaction = func(loopLength ui
 			let priority = FlowTransactionScheduler.Priority.High
 			let data = largeArray
 		`, arraySize)),
-	)
+	).SetAuthorizers(scheduleAuthorizer)
 }
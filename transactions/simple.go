@@ -4,8 +4,10 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"strings"
 
 	crypto2 "github.com/onflow/crypto"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 )
 
@@ -17,63 +19,63 @@ var EmptyLoopTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var AssertTrueTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"assert(true)",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetSignerAddressTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"signer.address",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetSignerPublicAccountTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"getAccount(signer.address)",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetSignerAccountBalanceTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"signer.balance",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetSignerAccountAvailableBalanceTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"signer.availableBalance",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetSignerAccountStorageUsedTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"signer.storage.used",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetSignerAccountStorageCapacityTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"signer.storage.capacity",
-	)
+	).SetAuthorizers(Signer())
 }
 
 var BorrowSignerAccountFlowTokenVaultTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"let vaultRef = signer.storage.borrow<auth(FungibleToken.Withdraw) &FlowToken.Vault>(from: /storage/flowTokenVault)!",
-	)
+	).SetAuthorizers(Signer(EntitlementBorrowValue))
 }
 
 var BorrowSignerAccountFungibleTokenReceiverTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -83,7 +85,7 @@ var BorrowSignerAccountFungibleTokenReceiverTransaction = func(loopLength uint64
 			let receiverRef = getAccount(signer.address)
 				.capabilities.borrow<&{FungibleToken.Receiver}>(/public/flowTokenReceiver)!
 			`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var TransferTokensToSelfTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -96,14 +98,16 @@ var TransferTokensToSelfTransaction = func(loopLength uint64) *SimpleTransaction
 				.capabilities.borrow<&{FungibleToken.Receiver}>(/public/flowTokenReceiver)!
 			receiverRef.deposit(from: <-vaultRef.withdraw(amount: 0.00001))
 			`,
-	)
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(flowTokenTransferEvents(loopLength)...)
 }
 
 var CreateNewAccountTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		"let acct = Account(payer: signer)",
-	)
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(ExpectedEvent{Type: flow.EventAccountCreated, Count: loopLength})
 }
 
 var CreateNewAccountWithContractTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -113,35 +117,128 @@ var CreateNewAccountWithContractTransaction = func(loopLength uint64) *SimpleTra
 			let acct = Account(payer: signer)
 			acct.contracts.add(name: "EmptyContract", code: "61636365737328616c6c2920636f6e747261637420456d707479436f6e7472616374207b7d".decodeHex())
 			`,
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(
+			ExpectedEvent{Type: flow.EventAccountCreated, Count: loopLength},
+			ExpectedEvent{Type: flow.EventAccountContractAdded, Count: loopLength},
+		)
+}
+
+// CreateNewAccountsWithKeysTransaction creates one account per public key, adds the key
+// keysPerAccount times with full weight so each copy can be used as a proposal key,
+// and funds every new account with fundingAmount (in UFix64 units) from the signer's vault.
+var CreateNewAccountsWithKeysTransaction = func(
+	publicKeys []crypto.PublicKey,
+	hashAlgorithm crypto.HashAlgorithm,
+	keysPerAccount uint64,
+	fundingAmount uint64,
+) *SimpleTransaction {
+	return must(NewCreateNewAccountsWithKeysTransaction(publicKeys, hashAlgorithm, keysPerAccount, fundingAmount))
+}
+
+func NewCreateNewAccountsWithKeysTransaction(
+	publicKeys []crypto.PublicKey,
+	hashAlgorithm crypto.HashAlgorithm,
+	keysPerAccount uint64,
+	fundingAmount uint64,
+) (*SimpleTransaction, error) {
+	numAccounts := uint64(len(publicKeys))
+	err := checkNonZero("number of public keys", numAccounts)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNonZero("keysPerAccount", keysPerAccount)
+	if err != nil {
+		return nil, err
+	}
+	hashAlgorithmName, err := cadenceHashAlgorithm(hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		if publicKey == nil {
+			return nil, invalidParameter("public key %d is nil", i)
+		}
+		signatureAlgorithmName, err := cadenceSignatureAlgorithm(publicKey.Algorithm())
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, fmt.Sprintf(`
+					PublicKey(
+						publicKey: "%s".decodeHex(),
+						signatureAlgorithm: SignatureAlgorithm.%s
+					)`,
+			hex.EncodeToString(publicKey.Encode()),
+			signatureAlgorithmName,
+		))
+	}
+
+	body := fmt.Sprintf(`
+				let vaultRef = signer.storage.borrow<auth(FungibleToken.Withdraw) &FlowToken.Vault>(from: /storage/flowTokenVault)!
+				let keys: [PublicKey] = [%s
+				]
+				for key in keys {
+					let acct = Account(payer: signer)
+					var k = 0
+					while k < %d {
+						k = k + 1
+						acct.keys.add(
+							publicKey: key,
+							hashAlgorithm: HashAlgorithm.%s,
+							weight: 1000.0
+						)
+					}
+					getAccount(acct.address)
+						.capabilities.borrow<&{FungibleToken.Receiver}>(/public/flowTokenReceiver)!
+						.deposit(from: <-vaultRef.withdraw(amount: %s))
+				}
+			`,
+		strings.Join(keys, ", "),
+		keysPerAccount,
+		hashAlgorithmName,
+		ufix64(fundingAmount),
 	)
+
+	return checkSize(NewSimpleTransaction(
+		body,
+	).SetAuthorizers(Signer(EntitlementBorrowValue)).
+		SetExpectedEvents(mergeExpectedEvents(
+			[]ExpectedEvent{
+				{Type: flow.EventAccountCreated, Count: numAccounts},
+				{Type: flow.EventAccountKeyAdded, Count: numAccounts * keysPerAccount},
+			},
+			flowTokenTransferEvents(numAccounts),
+		)...))
 }
 
 var DecodeHexTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`"f847b84000fb479cb398ab7e31d6f048c12ec5b5b679052589280cacde421af823f93fe927dfc3d1e371b172f97ceeac1bc235f60654184c83f4ea70dd3b7785ffb3c73802038203e8".decodeHex()`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var RevertibleRandomTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`revertibleRandom<UInt64>(modulo: UInt64(100))`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var NumberToStringConversionTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`i.toString()`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ConcatenateStringTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`"x".concat(i.toString())`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 // needs a string in storage
@@ -155,7 +252,7 @@ var BorrowStringTransaction = NewSimpleTransaction(
 			i = i + 1
 		}
 	`,
-)
+).SetAuthorizers(Signer(EntitlementBorrowValue))
 
 var CopyStringTransaction = NewSimpleTransaction(
 	`
@@ -167,7 +264,7 @@ var CopyStringTransaction = NewSimpleTransaction(
 			i = i + 1
 		}
 	`,
-)
+).SetAuthorizers(Signer(EntitlementCopyValue))
 
 var CopyStringAndSaveADuplicateTransaction = NewSimpleTransaction(
 	`
@@ -180,9 +277,21 @@ var CopyStringAndSaveADuplicateTransaction = NewSimpleTransaction(
 		}
 		signer.storage.save(strings, to: /storage/ACpStSv2)
 	`,
-)
+).SetAuthorizers(Signer(EntitlementCopyValue, EntitlementSaveValue))
 
 var StoreAndLoadDictStringTransaction = func(dictLen uint64) *SimpleTransaction {
+	return storeAndLoadDictStringTransaction(dictLen)
+}
+
+func NewStoreAndLoadDictStringTransaction(dictLen uint64) (*SimpleTransaction, error) {
+	err := checkLength("dictLen", dictLen, stringDictEntrySize(75))
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(storeAndLoadDictStringTransaction(dictLen))
+}
+
+func storeAndLoadDictStringTransaction(dictLen uint64) *SimpleTransaction {
 	return NewSimpleTransaction(
 		fmt.Sprintf(
 			`
@@ -191,7 +300,7 @@ var StoreAndLoadDictStringTransaction = func(dictLen uint64) *SimpleTransaction
 			`,
 			stringDictOfLen(dictLen, 75),
 		),
-	)
+	).SetAuthorizers(Signer(EntitlementSaveValue, EntitlementLoadValue))
 }
 
 var StoreLoadAndDestroyDictStringTransaction = NewSimpleTransaction(
@@ -201,7 +310,7 @@ var StoreLoadAndDestroyDictStringTransaction = NewSimpleTransaction(
 			strings.remove(key: key)
 		}
 	`,
-)
+).SetAuthorizers(Signer(EntitlementLoadValue))
 
 var BorrowDictStringTransaction = NewSimpleTransaction(
 	`
@@ -212,7 +321,7 @@ var BorrowDictStringTransaction = NewSimpleTransaction(
 			return true
 		})
 	`,
-)
+).SetAuthorizers(Signer(EntitlementBorrowValue))
 
 var CopyDictStringTransaction = NewSimpleTransaction(
 	`
@@ -223,7 +332,7 @@ var CopyDictStringTransaction = NewSimpleTransaction(
 			return true
 		})
 	`,
-)
+).SetAuthorizers(Signer(EntitlementCopyValue))
 
 var CopyDictStringAndSaveADuplicateTransaction = NewSimpleTransaction(
 	`
@@ -235,14 +344,14 @@ var CopyDictStringAndSaveADuplicateTransaction = NewSimpleTransaction(
 		})
 		signer.storage.save(strings, to: /storage/ACpDStSv2)
 	`,
-)
+).SetAuthorizers(Signer(EntitlementCopyValue, EntitlementSaveValue))
 
 var LoadDictAndDestroyItTransaction = NewSimpleTransaction(
 	`
 		let r <- signer.storage.load<@{String: AnyResource}>(from: /storage/DestDict)!
 		destroy r
 	`,
-)
+).SetAuthorizers(Signer(EntitlementLoadValue))
 
 var AddKeyToAccountTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
@@ -259,7 +368,70 @@ var AddKeyToAccountTransaction = func(loopLength uint64) *SimpleTransaction {
 					weight: 0.0
 				)
 			`,
+	).SetAuthorizers(Signer(EntitlementAddKey)).
+		SetExpectedEvents(ExpectedEvent{Type: flow.EventAccountKeyAdded, Count: loopLength})
+}
+
+// AddSigningKeyToAccountTransaction adds publicKey loopLength times with the given weight
+// (between 0 and 1000), so that the added keys can be used to sign transactions.
+var AddSigningKeyToAccountTransaction = func(
+	loopLength uint64,
+	publicKey crypto.PublicKey,
+	hashAlgorithm crypto.HashAlgorithm,
+	weight uint64,
+) *SimpleTransaction {
+	return must(NewAddSigningKeyToAccountTransaction(loopLength, publicKey, hashAlgorithm, weight))
+}
+
+func NewAddSigningKeyToAccountTransaction(
+	loopLength uint64,
+	publicKey crypto.PublicKey,
+	hashAlgorithm crypto.HashAlgorithm,
+	weight uint64,
+) (*SimpleTransaction, error) {
+	if publicKey == nil {
+		return nil, invalidParameter("public key is nil")
+	}
+	if weight > 1000 {
+		return nil, invalidParameter("weight %d exceeds 1000", weight)
+	}
+	signatureAlgorithmName, err := cadenceSignatureAlgorithm(publicKey.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+	hashAlgorithmName, err := cadenceHashAlgorithm(hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf(`
+				let key = PublicKey(
+					publicKey: "%s".decodeHex(),
+					signatureAlgorithm: SignatureAlgorithm.%s
+				)
+				%s
+			`,
+		hex.EncodeToString(publicKey.Encode()),
+		signatureAlgorithmName,
+		LoopTemplate(
+			loopLength,
+			fmt.Sprintf(`
+					signer.keys.add(
+						publicKey: key,
+						hashAlgorithm: HashAlgorithm.%s,
+						weight: %d.0
+					)
+				`,
+				hashAlgorithmName,
+				weight,
+			),
+		),
 	)
+
+	return NewSimpleTransaction(
+		body,
+	).SetAuthorizers(Signer(EntitlementAddKey)).
+		SetExpectedEvents(ExpectedEvent{Type: flow.EventAccountKeyAdded, Count: loopLength}), nil
 }
 
 var AddAndRevokeKeyToAccountTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -278,7 +450,11 @@ var AddAndRevokeKeyToAccountTransaction = func(loopLength uint64) *SimpleTransac
 				)
 				signer.keys.revoke(keyIndex: ac.keyIndex)
 			`,
-	)
+	).SetAuthorizers(Signer(EntitlementAddKey, EntitlementRevokeKey)).
+		SetExpectedEvents(
+			ExpectedEvent{Type: flow.EventAccountKeyAdded, Count: loopLength},
+			ExpectedEvent{Type: flow.EventAccountKeyRemoved, Count: loopLength},
+		)
 }
 
 var GetAccountKeyTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -287,38 +463,50 @@ var GetAccountKeyTransaction = func(loopLength uint64) *SimpleTransaction {
 		`
 				let key = signer.keys.get(keyIndex: 0)
 			`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetContractsTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`signer.contracts.names`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var HashTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`Crypto.hash("%s".utf8, algorithm: HashAlgorithm.SHA2_256)`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var StringToLowerTransaction = func(loopLength uint64, stringLen uint64) *SimpleTransaction {
+	return stringToLowerTransaction(loopLength, stringLen)
+}
+
+func NewStringToLowerTransaction(loopLength uint64, stringLen uint64) (*SimpleTransaction, error) {
+	err := checkLength("stringLen", stringLen, 1)
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(stringToLowerTransaction(loopLength, stringLen))
+}
+
+func stringToLowerTransaction(loopLength uint64, stringLen uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		fmt.Sprintf(`
 			var s = "%s"
 			s = s.toLower()
 		`, stringOfLen(stringLen)),
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetCurrentBlockTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`getCurrentBlock()`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var GetBlockAtTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -326,7 +514,7 @@ var GetBlockAtTransaction = func(loopLength uint64) *SimpleTransaction {
 		loopLength,
 		`let at = getCurrentBlock().height
 		getBlock(at: at)`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var DestroyResourceDictionaryTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -334,59 +522,64 @@ var DestroyResourceDictionaryTransaction = func(loopLength uint64) *SimpleTransa
 		loopLength,
 		`let r: @{String: AnyResource} <- {}
 		destroy r`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ParseUFix64Transaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`let smol: UFix64? = UFix64.fromString("0.123456")`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ParseFix64Transaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`let smol: Fix64? = Fix64.fromString("-0.123456")`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ParseUInt64Transaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`let smol: UInt64? = UInt64.fromString("123456")`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ParseInt64Transaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`let smol: Int64? = Int64.fromString("-123456")`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ParseIntTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`let smol: Int? = Int.fromString("-12345")`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var IssueStorageCapabilityTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`let cap = signer.capabilities.storage.issue<&Int>(/storage/foo)`,
-	)
+	).SetAuthorizers(Signer(EntitlementIssueStorageCapabilityController)).
+		SetExpectedEvents(ExpectedEvent{Type: EventStorageCapabilityControllerIssued, Count: loopLength})
 }
 
 var GetKeyCountTransaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		`let count = signer.keys.count`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var CreateKeyECDSAP256Transaction = func(loopLength uint64) *SimpleTransaction {
+	return must(NewCreateKeyECDSAP256Transaction(loopLength))
+}
+
+func NewCreateKeyECDSAP256Transaction(loopLength uint64) (*SimpleTransaction, error) {
 	seed := make([]byte, crypto.MinSeedLength)
 	for i := range seed {
 		seed[i] = 0
@@ -394,7 +587,7 @@ var CreateKeyECDSAP256Transaction = func(loopLength uint64) *SimpleTransaction {
 
 	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
 	}
 	key := hex.EncodeToString(privateKey.PublicKey().Encode())
 
@@ -408,7 +601,7 @@ var CreateKeyECDSAP256Transaction = func(loopLength uint64) *SimpleTransaction {
 	return simpleTransactionWithLoop(
 		loopLength,
 		body,
-	)
+	).SetAuthorizers(Signer()), nil
 }
 
 var CreateKeyEDCSAsecp256k1Transaction = func(loopLength uint64) *SimpleTransaction {
@@ -420,7 +613,7 @@ var CreateKeyEDCSAsecp256k1Transaction = func(loopLength uint64) *SimpleTransact
 				signatureAlgorithm: SignatureAlgorithm.ECDSA_secp256k1
 			)
 		`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var CreateKeyBLSBLS12381Transaction = func(loopLength uint64) *SimpleTransaction {
@@ -432,7 +625,7 @@ var CreateKeyBLSBLS12381Transaction = func(loopLength uint64) *SimpleTransaction
 				signatureAlgorithm: SignatureAlgorithm.BLS_BLS12_381
 			)
 		`,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ArrayInsertTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -450,7 +643,7 @@ var ArrayInsertTransaction = func(loopLength uint64) *SimpleTransaction {
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ArrayInsertRemoveTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -469,7 +662,7 @@ var ArrayInsertRemoveTransaction = func(loopLength uint64) *SimpleTransaction {
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ArrayInsertSetRemoveTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -489,7 +682,7 @@ var ArrayInsertSetRemoveTransaction = func(loopLength uint64) *SimpleTransaction
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ArrayInsertMapTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -512,7 +705,7 @@ var ArrayInsertMapTransaction = func(loopLength uint64) *SimpleTransaction {
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ArrayInsertFilterTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -534,7 +727,7 @@ var ArrayInsertFilterTransaction = func(loopLength uint64) *SimpleTransaction {
 	)
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var DictInsertTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -552,7 +745,7 @@ var DictInsertTransaction = func(loopLength uint64) *SimpleTransaction {
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var DictInsertRemoveTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -571,7 +764,7 @@ var DictInsertRemoveTransaction = func(loopLength uint64) *SimpleTransaction {
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var DictInsertSetRemoveTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -591,7 +784,7 @@ var DictInsertSetRemoveTransaction = func(loopLength uint64) *SimpleTransaction
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var DictIterCopyTransaction = func(loopLength uint64) *SimpleTransaction {
@@ -614,10 +807,23 @@ var DictIterCopyTransaction = func(loopLength uint64) *SimpleTransaction {
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
 var ArrayCreateBatchTransaction = func(loopLength uint64) *SimpleTransaction {
+	return arrayCreateBatchTransaction(loopLength)
+}
+
+func NewArrayCreateBatchTransaction(loopLength uint64) (*SimpleTransaction, error) {
+	// each element takes at most 7 bytes in the array literal
+	err := checkLength("loopLength", loopLength, 7)
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(arrayCreateBatchTransaction(loopLength))
+}
+
+func arrayCreateBatchTransaction(loopLength uint64) *SimpleTransaction {
 	sumStr := "0"
 	for i := 0; i < int(loopLength); i++ {
 		sumStr += fmt.Sprintf(",%d", i)
@@ -633,10 +839,36 @@ var ArrayCreateBatchTransaction = func(loopLength uint64) *SimpleTransaction {
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
+// VerifySignatureTransaction panics if it fails to generate the keys.
 var VerifySignatureTransaction = func(numKeys uint64, signatures []string) *SimpleTransaction {
+	return must(verifySignatureTransaction(numKeys, signatures))
+}
+
+func NewVerifySignatureTransaction(numKeys uint64, signatures []string) (*SimpleTransaction, error) {
+	err := checkNonZero("numKeys", numKeys)
+	if err != nil {
+		return nil, err
+	}
+	err = checkCount("signatures", len(signatures), "numKeys", numKeys)
+	if err != nil {
+		return nil, err
+	}
+	err = checkHex("signature", signatures[:numKeys])
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := verifySignatureTransaction(numKeys, signatures)
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(tx)
+}
+
+func verifySignatureTransaction(numKeys uint64, signatures []string) (*SimpleTransaction, error) {
 	message := []byte("hello world")
 
 	rawKeys := make([]string, numKeys)
@@ -646,17 +878,17 @@ var VerifySignatureTransaction = func(numKeys uint64, signatures []string) *Simp
 		seed := make([]byte, crypto.MinSeedLength)
 		_, err := rand.Read(seed)
 		if err != nil {
-			panic(fmt.Errorf("failed to generate seed: %w", err))
+			return nil, fmt.Errorf("failed to generate seed: %w", err)
 		}
 
 		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
 		if err != nil {
-			panic(fmt.Errorf("failed to generate private key: %w", err))
+			return nil, fmt.Errorf("failed to generate private key: %w", err)
 		}
 		rawKeys[i] = hex.EncodeToString(privateKey.PublicKey().Encode())
 		sig, err := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
 		if err != nil {
-			panic(fmt.Errorf("failed to generate signer: %w", err))
+			return nil, fmt.Errorf("failed to generate signer: %w", err)
 		}
 		signers[i] = sig
 	}
@@ -715,10 +947,30 @@ var VerifySignatureTransaction = func(numKeys uint64, signatures []string) *Simp
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer()), nil
 }
 
 var AggregateBLSAggregateSignatureTransaction = func(numSigs int, sigs []string) *SimpleTransaction {
+	return aggregateBLSAggregateSignatureTransaction(numSigs, sigs)
+}
+
+func NewAggregateBLSAggregateSignatureTransaction(numSigs int, sigs []string) (*SimpleTransaction, error) {
+	err := checkNumSigs(numSigs)
+	if err != nil {
+		return nil, err
+	}
+	err = checkCount("signatures", len(sigs), "numSigs", uint64(numSigs))
+	if err != nil {
+		return nil, err
+	}
+	err = checkHex("signature", sigs[:numSigs])
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(aggregateBLSAggregateSignatureTransaction(numSigs, sigs))
+}
+
+func aggregateBLSAggregateSignatureTransaction(numSigs int, sigs []string) *SimpleTransaction {
 	signatures := ""
 	for i := 0; i < numSigs; i++ {
 		signatures += fmt.Sprintf(`
@@ -734,27 +986,45 @@ var AggregateBLSAggregateSignatureTransaction = func(numSigs int, sigs []string)
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
+// AggregateBLSAggregateKeysTransaction panics if it fails to generate the keys.
 var AggregateBLSAggregateKeysTransaction = func(numSigs int) *SimpleTransaction {
-	pks := make([]crypto2.PublicKey, 0, numSigs)
+	return must(aggregateBLSAggregateKeysTransaction(numSigs))
+}
+
+func NewAggregateBLSAggregateKeysTransaction(numSigs int) (*SimpleTransaction, error) {
+	err := checkNumSigs(numSigs)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := aggregateBLSAggregateKeysTransaction(numSigs)
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(tx)
+}
+
+func aggregateBLSAggregateKeysTransaction(numSigs int) (*SimpleTransaction, error) {
+	pks := make([]crypto2.PublicKey, 0, max(numSigs, 0))
 	signatureAlgorithm := crypto2.BLSBLS12381
 	input := make([]byte, 100)
 	_, err := rand.Read(input)
 	if err != nil {
-		panic(fmt.Errorf("failed to generate random data to sign: %w", err))
+		return nil, fmt.Errorf("failed to generate random data to sign: %w", err)
 	}
 
 	for i := 0; i < numSigs; i++ {
 		seed := make([]byte, crypto2.KeyGenSeedMinLen)
 		_, err := rand.Read(seed)
 		if err != nil {
-			panic(fmt.Errorf("failed to generate seed: %w", err))
+			return nil, fmt.Errorf("failed to generate seed: %w", err)
 		}
 		sk, err := crypto.GeneratePrivateKey(signatureAlgorithm, seed)
 		if err != nil {
-			panic(fmt.Errorf("failed to generate private key: %w", err))
+			return nil, fmt.Errorf("failed to generate private key: %w", err)
 		}
 
 		pks = append(pks, sk.PublicKey())
@@ -778,10 +1048,43 @@ var AggregateBLSAggregateKeysTransaction = func(numSigs int) *SimpleTransaction
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer()), nil
 }
 
 var BLSVerifySignatureTransaction = func(numSigs int, pks []crypto2.PublicKey, signatures []string) *SimpleTransaction {
+	return blsVerifySignatureTransaction(numSigs, pks, signatures)
+}
+
+func NewBLSVerifySignatureTransaction(
+	numSigs int,
+	pks []crypto2.PublicKey,
+	signatures []string,
+) (*SimpleTransaction, error) {
+	err := checkNumSigs(numSigs)
+	if err != nil {
+		return nil, err
+	}
+	err = checkCount("public keys", len(pks), "numSigs", uint64(numSigs))
+	if err != nil {
+		return nil, err
+	}
+	err = checkCount("signatures", len(signatures), "numSigs", uint64(numSigs))
+	if err != nil {
+		return nil, err
+	}
+	for i, pk := range pks[:numSigs] {
+		if pk == nil || pk.Algorithm() != crypto2.BLSBLS12381 {
+			return nil, invalidParameter("public key %d is not a BLS public key", i)
+		}
+	}
+	err = checkHex("signature", signatures[:numSigs])
+	if err != nil {
+		return nil, err
+	}
+	return checkSize(blsVerifySignatureTransaction(numSigs, pks, signatures))
+}
+
+func blsVerifySignatureTransaction(numSigs int, pks []crypto2.PublicKey, signatures []string) *SimpleTransaction {
 	message := []byte("random_message")
 
 	signaturesString := ""
@@ -819,25 +1122,30 @@ var BLSVerifySignatureTransaction = func(numSigs int, pks []crypto2.PublicKey, s
 			`, pkString, signaturesString, hex.EncodeToString(message))
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer())
 }
 
+// BLSVerifyProofOfPossessionTransaction panics if it fails to generate the key or its proof of possession.
 var BLSVerifyProofOfPossessionTransaction = func(loopLength uint64) *SimpleTransaction {
+	return must(NewBLSVerifyProofOfPossessionTransaction(loopLength))
+}
+
+func NewBLSVerifyProofOfPossessionTransaction(loopLength uint64) (*SimpleTransaction, error) {
 	signatureAlgorithm := crypto2.BLSBLS12381
 	seed := make([]byte, crypto2.KeyGenSeedMinLen)
 	_, err := rand.Read(seed)
 	if err != nil {
-		panic(fmt.Errorf("failed to generate seed: %w", err))
+		return nil, fmt.Errorf("failed to generate seed: %w", err)
 	}
 	sk, err := crypto.GeneratePrivateKey(signatureAlgorithm, seed)
 	if err != nil {
-		panic(fmt.Errorf("failed to generate private key: %w", err))
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
 	}
 	pk := sk.PublicKey()
 
 	proof, err := crypto2.BLSGeneratePOP(sk)
 	if err != nil {
-		panic(fmt.Errorf("failed to generate proof of possession: %w", err))
+		return nil, fmt.Errorf("failed to generate proof of possession: %w", err)
 	}
 
 	body := fmt.Sprintf(`
@@ -859,5 +1167,5 @@ var BLSVerifyProofOfPossessionTransaction = func(loopLength uint64) *SimpleTrans
 
 	return NewSimpleTransaction(
 		body,
-	)
+	).SetAuthorizers(Signer()), nil
 }
@@ -0,0 +1,40 @@
+package transactions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// requireParses renders tx and fails the test if the Cadence parser rejects the source.
+func requireParses(t *testing.T, tx Transaction) {
+	t.Helper()
+
+	source := Render(tx, nil)
+	_, err := parser.ParseProgram(nil, []byte(source), parser.Config{})
+	if err != nil {
+		t.Fatalf("rendered source does not parse: %s\n%s", err, source)
+	}
+}
+
+func TestCreateNewAccountsWithKeysTransactionParses(t *testing.T) {
+	for _, numKeys := range []int{1, 3} {
+		publicKeys := make([]crypto.PublicKey, 0, numKeys)
+		for i := range numKeys {
+			seed := bytes.Repeat([]byte{byte(i + 1)}, crypto.MinSeedLength)
+			privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			publicKeys = append(publicKeys, privateKey.PublicKey())
+		}
+
+		tx, err := NewCreateNewAccountsWithKeysTransaction(publicKeys, crypto.SHA3_256, 2, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireParses(t, tx)
+	}
+}
@@ -0,0 +1,9 @@
+package transactions
+
+// Template constructs transactions from parameter values.
+type Template interface {
+	// Parameters returns the parameter schema of the template.
+	Parameters() Parameters
+	// New resolves the values against the parameter schema and constructs the transaction.
+	New(values map[string]any) (Transaction, error)
+}
@@ -2,12 +2,20 @@ package transactions
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 )
 
 type SimpleTransaction struct {
 	prepareBlock      string
 	executeBlock      string
 	fieldDeclarations string
+	preConditions     string
+	postConditions    string
+	authorizers       []Authorizer
+	expectedEvents    []ExpectedEvent
+	expectedFailure   *ExpectedFailure
+	imports           Imports
 }
 
 var _ Transaction = (*SimpleTransaction)(nil)
@@ -20,6 +28,28 @@ func NewSimpleTransaction(
 	}
 }
 
+// Copy returns a copy of the transaction, which can be modified with the setters
+// without affecting the original.
+func (s *SimpleTransaction) Copy() *SimpleTransaction {
+	c := *s
+	if s.authorizers != nil {
+		c.authorizers = make([]Authorizer, len(s.authorizers))
+		for i, authorizer := range s.authorizers {
+			c.authorizers[i] = Authorizer{
+				Name:         authorizer.Name,
+				Entitlements: slices.Clone(authorizer.Entitlements),
+			}
+		}
+	}
+	c.expectedEvents = slices.Clone(s.expectedEvents)
+	if s.expectedFailure != nil {
+		expectedFailure := *s.expectedFailure
+		c.expectedFailure = &expectedFailure
+	}
+	c.imports = maps.Clone(s.imports)
+	return &c
+}
+
 func (s *SimpleTransaction) SetPrepareBlock(
 	prepareBlock string,
 ) *SimpleTransaction {
@@ -41,6 +71,55 @@ func (s *SimpleTransaction) SetFieldDeclarations(
 	return s
 }
 
+func (s *SimpleTransaction) SetPreConditions(
+	preConditions string,
+) *SimpleTransaction {
+	s.preConditions = preConditions
+	return s
+}
+
+func (s *SimpleTransaction) SetPostConditions(
+	postConditions string,
+) *SimpleTransaction {
+	s.postConditions = postConditions
+	return s
+}
+
+// SetAuthorizers declares the authorizers of the transaction and their entitlements.
+// Calling it without authorizers declares a transaction without authorizers.
+func (s *SimpleTransaction) SetAuthorizers(
+	authorizers ...Authorizer,
+) *SimpleTransaction {
+	if authorizers == nil {
+		authorizers = []Authorizer{}
+	}
+	s.authorizers = authorizers
+	return s
+}
+
+func (s *SimpleTransaction) SetExpectedEvents(
+	expectedEvents ...ExpectedEvent,
+) *SimpleTransaction {
+	s.expectedEvents = expectedEvents
+	return s
+}
+
+func (s *SimpleTransaction) SetExpectedFailure(
+	expectedFailure *ExpectedFailure,
+) *SimpleTransaction {
+	s.expectedFailure = expectedFailure
+	return s
+}
+
+// SetImports sets the contracts the transaction imports in addition to the imports passed to Render,
+// which take precedence.
+func (s *SimpleTransaction) SetImports(
+	imports Imports,
+) *SimpleTransaction {
+	s.imports = imports
+	return s
+}
+
 func (s *SimpleTransaction) GetPrepareBlock() string {
 	return s.prepareBlock
 }
@@ -53,6 +132,30 @@ func (s *SimpleTransaction) GetFieldDeclarations() string {
 	return s.fieldDeclarations
 }
 
+func (s *SimpleTransaction) GetPreConditions() string {
+	return s.preConditions
+}
+
+func (s *SimpleTransaction) GetPostConditions() string {
+	return s.postConditions
+}
+
+func (s *SimpleTransaction) GetAuthorizers() []Authorizer {
+	return s.authorizers
+}
+
+func (s *SimpleTransaction) GetExpectedEvents() []ExpectedEvent {
+	return s.expectedEvents
+}
+
+func (s *SimpleTransaction) GetExpectedFailure() *ExpectedFailure {
+	return s.expectedFailure
+}
+
+func (s *SimpleTransaction) GetImports() Imports {
+	return s.imports
+}
+
 func LoopTemplate(
 	n uint64,
 	body string,
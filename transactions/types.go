@@ -8,10 +8,25 @@ type Transaction interface {
 	SetPrepareBlock(prepareBlock string) *SimpleTransaction
 	SetExecuteBlock(executeBlock string) *SimpleTransaction
 	SetFieldDeclarations(fieldDeclarations string) *SimpleTransaction
+	SetPreConditions(preConditions string) *SimpleTransaction
+	SetPostConditions(postConditions string) *SimpleTransaction
+	SetAuthorizers(authorizers ...Authorizer) *SimpleTransaction
+	SetExpectedEvents(expectedEvents ...ExpectedEvent) *SimpleTransaction
+	SetExpectedFailure(expectedFailure *ExpectedFailure) *SimpleTransaction
+	SetImports(imports Imports) *SimpleTransaction
 
 	GetPrepareBlock() string
 	GetExecuteBlock() string
 	GetFieldDeclarations() string
+	GetPreConditions() string
+	GetPostConditions() string
+	// GetAuthorizers returns nil if the transaction does not declare its authorizers.
+	GetAuthorizers() []Authorizer
+	GetExpectedEvents() []ExpectedEvent
+	// GetExpectedFailure returns nil if the transaction is expected to succeed.
+	GetExpectedFailure() *ExpectedFailure
+	// GetImports returns the contracts the transaction imports in addition to the imports passed to Render.
+	GetImports() Imports
 }
 
 type Registry interface {
@@ -0,0 +1,148 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MaxTransactionByteSize is the maximum byte size of a transaction accepted by Flow networks.
+const MaxTransactionByteSize = 1_500_000
+
+// ErrInvalidParameter is wrapped by the errors of the New...Transaction constructors
+// for invalid parameter values. The corresponding ...Transaction constructors of the original templates
+// build the transaction without validating the parameters, as they always did,
+// and the constructors of the templates added since panic instead.
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+func invalidParameter(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidParameter, fmt.Sprintf(format, args...))
+}
+
+// must returns tx, and panics if err is not nil.
+// It is used by the constructors that panic on invalid parameters.
+func must(tx *SimpleTransaction, err error) *SimpleTransaction {
+	if err != nil {
+		panic(err)
+	}
+	return tx
+}
+
+func checkNonZero(name string, value uint64) error {
+	if value == 0 {
+		return invalidParameter("%s must not be zero", name)
+	}
+	return nil
+}
+
+// checkLength checks that length elements of elementSize bytes fit into a transaction,
+// so oversized lengths are rejected before the source code is built. Zero lengths are valid.
+func checkLength(name string, length uint64, elementSize uint64) error {
+	if length > MaxTransactionByteSize/elementSize {
+		return invalidParameter(
+			"%s %d exceeds the maximum transaction size of %d bytes",
+			name,
+			length,
+			MaxTransactionByteSize,
+		)
+	}
+	return nil
+}
+
+// checkChunkLength checks that the length is not zero, as loop lengths are derived by dividing by it,
+// and that a chunk of length bytes fits into a transaction.
+func checkChunkLength(name string, length uint64) error {
+	if length == 0 {
+		return invalidParameter("%s must not be zero", name)
+	}
+	return checkLength(name, length, 1)
+}
+
+// checkCount checks that at least count values were given for count, e.g. one signature per key.
+func checkCount(name string, length int, countName string, count uint64) error {
+	if uint64(length) < count {
+		return invalidParameter("got %d %s for %s %d", length, name, countName, count)
+	}
+	return nil
+}
+
+func checkHex(name string, values []string) error {
+	for i, value := range values {
+		_, err := hex.DecodeString(value)
+		if err != nil {
+			return invalidParameter("%s %d is not hex-encoded: %s", name, i, err)
+		}
+	}
+	return nil
+}
+
+// checkSize checks that the rendered transaction does not exceed MaxTransactionByteSize.
+// It checks an upper bound of the rendered size, see renderedSizeBound, so large transactions
+// are not rendered an extra time.
+func checkSize(tx *SimpleTransaction) (*SimpleTransaction, error) {
+	size := renderedSizeBound(tx)
+	if size > MaxTransactionByteSize {
+		return nil, invalidParameter(
+			"transaction of up to %d bytes exceeds the maximum of %d bytes",
+			size,
+			MaxTransactionByteSize,
+		)
+	}
+	return tx, nil
+}
+
+// renderOverhead is more than the bytes Render adds around the blocks of a transaction,
+// apart from the authorizer parameters.
+const renderOverhead = 128
+
+// renderedSizeBound returns an upper bound of the size of tx rendered without imports.
+// Render expands each tab to four spaces and indents each line by at most eight spaces.
+func renderedSizeBound(tx *SimpleTransaction) int {
+	size := renderOverhead
+
+	authorizers := tx.GetAuthorizers()
+	if authorizers == nil {
+		authorizers = DefaultAuthorizers
+	}
+	for _, authorizer := range authorizers {
+		size += len(authorizer.Parameter()) + len(", ")
+	}
+
+	for _, block := range []string{
+		tx.GetFieldDeclarations(),
+		tx.GetPrepareBlock(),
+		tx.GetPreConditions(),
+		tx.GetExecuteBlock(),
+		tx.GetPostConditions(),
+	} {
+		lines := strings.Count(block, "\n") + 1
+		tabs := strings.Count(block, "\t")
+		size += len(block) + 3*tabs + 8*lines
+	}
+	return size
+}
+
+func checkNumSigs(numSigs int) error {
+	if numSigs <= 0 {
+		return invalidParameter("numSigs must be positive, got %d", numSigs)
+	}
+	return nil
+}
+
+func checkFraction(fraction float64) error {
+	if math.IsNaN(fraction) || math.IsInf(fraction, 0) || fraction < 0 {
+		return invalidParameter("fraction must be a non-negative number, got %v", fraction)
+	}
+	return nil
+}
+
+// loopLength converts a loop length computed from the parameters,
+// which must not exceed the largest loop length.
+func loopLength(length float64) (uint64, error) {
+	if length >= math.MaxUint64 {
+		return 0, invalidParameter("loop length %g exceeds the maximum of %d", length, uint64(math.MaxUint64))
+	}
+	return uint64(length), nil
+}
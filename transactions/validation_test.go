@@ -0,0 +1,47 @@
+package transactions
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestLegacyConstructorsAcceptUnvalidatedParameters checks that the constructors of the original templates
+// still build transactions for parameters the validating constructors reject.
+func TestLegacyConstructorsAcceptUnvalidatedParameters(t *testing.T) {
+	const oversized = MaxTransactionByteSize
+
+	_, err := NewVerifySignatureTransaction(0, nil)
+	if !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("expected invalid parameter error, got %v", err)
+	}
+	VerifySignatureTransaction(0, nil)
+	AggregateBLSAggregateSignatureTransaction(0, nil)
+	AggregateBLSAggregateKeysTransaction(0)
+
+	_, err = NewStringToLowerTransaction(1, oversized)
+	if !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("expected invalid parameter error, got %v", err)
+	}
+	StringToLowerTransaction(1, oversized)
+}
+
+func TestRenderedSizeBound(t *testing.T) {
+	for _, tx := range []*SimpleTransaction{
+		NewSimpleTransaction(""),
+		StringToLowerTransaction(10, 1000),
+		EmitEventWithStringTransaction(100),
+		ArrayCreateBatchTransaction(100),
+		NewSimpleTransaction("\t\tlet x = 1\n\t\t\tlet y = 2").
+			SetExecuteBlock("\n\tlog(1)\n").
+			SetFieldDeclarations("let a: Int").
+			SetPreConditions("true").
+			SetPostConditions("true").
+			SetAuthorizers(Signer(EntitlementSaveValue), Authorizer{Name: "other"}),
+	} {
+		size := len(Render(tx, nil))
+		bound := renderedSizeBound(tx)
+		if bound < size {
+			t.Errorf("bound %d is less than the rendered size %d", bound, size)
+		}
+	}
+}